@@ -0,0 +1,54 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+
+	envtypes "github.com/nutanix-cloud-native/prism-go-client/environment/types"
+)
+
+// APIKeyUsername is the username of endpoints authenticating with a Prism Central API key, whose password is the key.
+// prism-go-client only knows basic credentials, so API keys travel through its username and password fields.
+const APIKeyUsername = "$api-key"
+
+// apiKeyHeader is the header of Prism Central IAM API keys
+const apiKeyHeader = "X-Ntnx-Api-Key"
+
+// apiKey returns the API key of an endpoint, or an empty string for basic credentials
+func apiKey(endpoint envtypes.ManagementEndpoint) string {
+	if endpoint.Username != APIKeyUsername {
+		return ""
+	}
+
+	return endpoint.Password
+}
+
+// APICredentials returns the credentials of either a username and password or an API key
+func APICredentials(username, password, key string) (envtypes.ApiCredentials, error) {
+	if key != "" {
+		if username != "" || password != "" {
+			return envtypes.ApiCredentials{}, fmt.Errorf("either an API key or a username and password are allowed, not both")
+		}
+		return envtypes.ApiCredentials{Username: APIKeyUsername, Password: key}, nil
+	}
+	if username == "" || password == "" {
+		return envtypes.ApiCredentials{}, fmt.Errorf("a username and password or an API key are required")
+	}
+
+	return envtypes.ApiCredentials{Username: username, Password: password}, nil
+}
+
+// apiKeyTransport replaces the basic credentials of requests by an API key
+type apiKeyTransport struct {
+	key  string
+	base http.RoundTripper
+}
+
+// RoundTrip implements the RoundTripper interface
+func (t *apiKeyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Del("Authorization")
+	req.Header.Set(apiKeyHeader, t.key)
+
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// authRecorder is a test Prism Central recording the authentication of its requests
+type authRecorder struct {
+	mu       sync.Mutex
+	requests []string
+}
+
+// record returns how a request authenticated: with an API key, basic credentials or the session cookie
+func (r *authRecorder) record(req *http.Request) string {
+	auth := "none"
+	if _, _, ok := req.BasicAuth(); ok {
+		auth = "basic"
+	}
+	if key := req.Header.Get(apiKeyHeader); key != "" {
+		auth = "key:" + key
+		if req.Header.Get("Authorization") != "" {
+			auth += "+basic"
+		}
+	}
+	if cookie, err := req.Cookie("session"); err == nil {
+		auth += "+cookie:" + cookie.Value
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req.URL.Path+" "+auth)
+
+	return auth
+}
+
+func (r *authRecorder) recorded() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.requests...)
+}
+
+func TestAPIKeyAuthentication(t *testing.T) {
+	recorder := &authRecorder{}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if recorder.record(r) != "key:secret-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/actuator/versionroutes":
+			_, _ = w.Write([]byte(`[{"namespace":"vmm","versionRoutes":[]}]`))
+		case "/api/clustermgmt/v4.0/config/clusters":
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	creds, err := APICredentials("", "", "secret-key")
+	require.NoError(t, err)
+	InitProfile("auth-api-key", NewMCPModelContextClient(map[string]string{
+		"endpoint": u.Hostname(),
+		"port":     u.Port(),
+		"username": creds.Username,
+		"password": creds.Password,
+		"insecure": "true",
+	}))
+	prismClient, err := LookupProfile("auth-api-key")
+	require.NoError(t, err)
+
+	v3Client, err := prismClient.V3()
+	require.NoError(t, err)
+	_, err = v3Client.GetPrismCentral(context.Background())
+	require.NoError(t, err)
+
+	_, err = prismClient.Get(context.Background(), "/api/clustermgmt/v4.0/config/clusters", nil)
+	require.NoError(t, err)
+
+	routes, err := prismClient.VersionRoutes(context.Background())
+	require.NoError(t, err)
+	require.Len(t, routes, 1)
+	assert.Equal(t, "vmm", routes[0].Namespace)
+
+	v4Client, err := prismClient.V4()
+	require.NoError(t, err)
+	_, err = v4Client.ClustersApiInstance.ListClusters(nil, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	// Every request carries the API key and no basic credentials
+	requests := recorder.recorded()
+	assert.Len(t, requests, 4)
+	for _, request := range requests {
+		assert.Contains(t, request, " key:secret-key")
+		assert.NotContains(t, request, "basic")
+	}
+}
+
+func TestAPICredentials(t *testing.T) {
+	creds, err := APICredentials("admin", "secret", "")
+	require.NoError(t, err)
+	assert.Equal(t, "admin", creds.Username)
+
+	_, err = APICredentials("admin", "", "")
+	assert.Error(t, err)
+	_, err = APICredentials("admin", "secret", "key")
+	assert.Error(t, err)
+}
+
+func TestSessionReuse(t *testing.T) {
+	recorder := &authRecorder{}
+	var (
+		mu      sync.Mutex
+		session = "first"
+	)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := recorder.record(r)
+
+		mu.Lock()
+		defer mu.Unlock()
+		switch auth {
+		case "basic", "basic+cookie:" + session:
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: session, Path: "/"})
+		case "none+cookie:" + session:
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	prismClient := initTestProfile(t, "auth-session", server.URL, true)
+	get := func() {
+		_, err := prismClient.Get(context.Background(), "/api/clustermgmt/v4.0/config/clusters", nil)
+		require.NoError(t, err)
+	}
+
+	// The basic credentials are sent once, then the session cookie is reused
+	get()
+	get()
+
+	// When the session expires, the client logs in again
+	mu.Lock()
+	session = "second"
+	mu.Unlock()
+	get()
+	get()
+
+	path := "/api/clustermgmt/v4.0/config/clusters "
+	assert.Equal(t, []string{
+		path + "basic",
+		path + "none+cookie:first",
+		path + "none+cookie:first",
+		path + "basic",
+		path + "none+cookie:second",
+	}, recorder.recorded())
+}
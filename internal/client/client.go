@@ -1,8 +1,13 @@
 package client
 
 import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
 	"github.com/nutanix-cloud-native/prism-go-client/environment"
-	"github.com/nutanix-cloud-native/prism-go-client/environment/providers/local"
 	"github.com/nutanix-cloud-native/prism-go-client/environment/providers/mcp"
 	envtypes "github.com/nutanix-cloud-native/prism-go-client/environment/types"
 	prismclientv3 "github.com/nutanix-cloud-native/prism-go-client/v3"
@@ -10,66 +15,243 @@ import (
 	"k8s.io/klog"
 )
 
+// DefaultProfile is the connection profile used when no profile is selected.
+// It is the only profile that also reads the NUTANIX_* environment variables, see EnvProvider.
+const DefaultProfile = "default"
+
 var (
-	prismClient *NutanixClient
+	profilesMu sync.RWMutex
+	profiles   = map[string]*NutanixClient{}
+
+	// The caches are shared by all profiles, whose clients are cached under separate keys.
+	// Clients with basic credentials log in once and reuse the session cookie, refreshing it when it expires.
+	// The v4 clients are cached by cachedV4Client.
+	v3ClientCache = prismclientv3.NewClientCache(prismclientv3.WithSessionAuth(true))
+
+	// API keys are sent with every request, so their clients don't log in
+	v3APIKeyClientCache = prismclientv3.NewClientCache()
 )
 
+// Init initializes the default connection profile
 func Init(modelcontextclient mcp.ModelContextClient) {
-	prismClient = &NutanixClient{
-		env:           environment.NewEnvironment(local.NewProvider(), mcp.NewProvider(modelcontextclient)),
-		v3ClientCache: prismclientv3.NewClientCache(),
-		v4ClientCache: prismclientv4.NewClientCache(),
+	InitProfile(DefaultProfile, modelcontextclient)
+}
+
+// InitProfile initializes a named connection profile, replacing the profile of the same name.
+// It reports whether a profile was replaced.
+func InitProfile(name string, modelcontextclient mcp.ModelContextClient) bool {
+	profilesMu.Lock()
+	defer profilesMu.Unlock()
+
+	return installProfile(newProfileClient(name, modelcontextclient))
+}
+
+// InitCheckedProfile initializes a named connection profile like InitProfile, but only if check succeeds with its client.
+// The client is checked before it replaces the profile of the same name, so a failed check leaves that profile in use.
+func InitCheckedProfile(name string, modelcontextclient mcp.ModelContextClient, check func(*NutanixClient) error) (bool, error) {
+	candidate := newProfileClient(name, modelcontextclient)
+	candidate.candidate = true
+	err := check(candidate)
+	// The candidate's clients are cached under their own key, drop them whatever the outcome
+	deleteClients(candidate)
+	if err != nil {
+		return false, err
+	}
+
+	return InitProfile(name, modelcontextclient), nil
+}
+
+// newProfileClient returns the client of a connection profile, which is not installed yet
+func newProfileClient(name string, modelcontextclient mcp.ModelContextClient) *NutanixClient {
+	providers := []envtypes.Provider{mcp.NewProvider(modelcontextclient)}
+	if name == DefaultProfile {
+		providers = append([]envtypes.Provider{EnvProvider}, providers...)
+	}
+
+	return &NutanixClient{
+		profile: name,
+		env:     environment.NewEnvironment(providers...),
+		rest:    &restSession{},
+	}
+}
+
+// installProfile installs the client of a connection profile and reports whether it replaced one.
+// The caller holds profilesMu.
+func installProfile(n *NutanixClient) bool {
+	previous, replaced := profiles[n.profile]
+	if replaced {
+		// Drop the clients of the replaced profile so that the next call connects anew
+		deleteClients(previous)
+	}
+	profiles[n.profile] = n
+
+	return replaced
+}
+
+// deleteClients drops the cached clients of a profile client
+func deleteClients(n *NutanixClient) {
+	v3ClientCache.Delete(n)
+	v3APIKeyClientCache.Delete(n)
+	deleteV4Client(n)
+}
+
+// GetPrismClient returns the client of the default connection profile,
+// or an ErrNotConfigured error if the credentials have not been set yet
+func GetPrismClient() (*NutanixClient, error) {
+	return LookupProfile(DefaultProfile)
+}
+
+// GetProfileClient returns the client of a connection profile, or nil if the profile is not configured.
+// An empty name selects the default profile.
+func GetProfileClient(name string) *NutanixClient {
+	if name == "" {
+		name = DefaultProfile
+	}
+
+	profilesMu.RLock()
+	defer profilesMu.RUnlock()
+
+	return profiles[name]
+}
+
+// LookupProfile returns the client of a connection profile, or an ErrNotConfigured error naming the available profiles.
+// An empty name selects the default profile.
+func LookupProfile(name string) (*NutanixClient, error) {
+	if prismClient := GetProfileClient(name); prismClient != nil {
+		return prismClient, nil
 	}
+	if name == "" || name == DefaultProfile {
+		return nil, newError(ErrNotConfigured, name, fmt.Errorf("prism client not initialized, please set credentials first"))
+	}
+
+	return nil, newError(ErrNotConfigured, name, fmt.Errorf("unknown profile %q, available: %s", name, strings.Join(ProfileNames(), ", ")))
 }
 
-func GetPrismClient() *NutanixClient {
-	if prismClient == nil {
-		panic("Prism client not initialized. Call Init() first.")
+// ProfileNames returns the sorted names of the configured connection profiles
+func ProfileNames() []string {
+	profilesMu.RLock()
+	defer profilesMu.RUnlock()
+
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
 	}
+	sort.Strings(names)
 
-	return prismClient
+	return names
 }
 
 type NutanixClient struct {
-	env           envtypes.Environment
-	v3ClientCache *prismclientv3.ClientCache
-	v4ClientCache *prismclientv4.ClientCache
+	profile string
+	env     envtypes.Environment
+	rest    *restSession
+	// candidate is set on clients checked before they are installed, see InitCheckedProfile
+	candidate bool
+}
+
+// Profile returns the name of the connection profile of the client
+func (n *NutanixClient) Profile() string {
+	return n.profile
 }
 
-// GetV3Client returns the v3 client
-func (n *NutanixClient) V3() prismclientv3.Service {
-	c, err := n.v3ClientCache.GetOrCreate(n)
+// V3 returns the v3 client, or a typed error if the profile is not configured or the client cannot be created
+func (n *NutanixClient) V3() (prismclientv3.Service, error) {
+	// The cache dereferences the endpoint address, so make sure there is one first
+	conn, err := n.connection()
+	if err != nil {
+		return nil, err
+	}
+	transport, err := n.rest.transport(conn)
+	if err != nil {
+		return nil, newError(ErrTLS, n.profile, err)
+	}
+
+	var c *prismclientv3.Client
+	if key := apiKey(conn.ManagementEndpoint); key != "" {
+		c, err = v3APIKeyClientCache.GetOrCreate(n, prismclientv3.WithRoundTripper(&apiKeyTransport{key: key, base: transport}))
+	} else {
+		c, err = v3ClientCache.GetOrCreate(n, prismclientv3.WithRoundTripper(transport))
+	}
 	if err != nil {
-		panic(err)
+		return nil, Classify(n.profile, fmt.Errorf("failed to create v3 client: %w", err))
 	}
 
-	return c.V3
+	return c.V3, nil
 }
 
-// GetV4Client returns the v4 client
-func (n *NutanixClient) V4() *prismclientv4.Client {
-	c, err := n.v4ClientCache.GetOrCreate(n)
+// V4 returns the v4 client, or a typed error if the profile is not configured or the client cannot be created.
+// Its ActuatorApiInstance is nil, use VersionRoutes instead.
+func (n *NutanixClient) V4() (*prismclientv4.Client, error) {
+	conn, err := n.connection()
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	return c
+	return cachedV4Client(n, conn)
 }
 
-// Key returns the constant client name
+// Key returns the client name of the connection profile
 // This implements the CachedClientParams interface of prism-go-client
 func (n *NutanixClient) Key() string {
-	return "mcp-server"
+	if n.candidate {
+		return fmt.Sprintf("mcp-server/%s/candidate", n.profile)
+	}
+	return fmt.Sprintf("mcp-server/%s", n.profile)
 }
 
-// ManagementEndpoint returns the management endpoint of the Nutanix cluster
+// ManagementEndpoint returns the management endpoint of the Nutanix cluster, or an empty endpoint if it is not configured
 // This implements the CachedClientParams interface of prism-go-client
 func (n *NutanixClient) ManagementEndpoint() envtypes.ManagementEndpoint {
-	mgmtEndpoint, err := n.env.GetManagementEndpoint(envtypes.Topology{})
+	mgmtEndpoint, err := n.Endpoint()
 	if err != nil {
 		klog.Errorf("failed to get management endpoint: %s", err.Error())
 		return envtypes.ManagementEndpoint{}
 	}
 
-	return *mgmtEndpoint
+	return mgmtEndpoint
+}
+
+// Endpoint returns the management endpoint of the Nutanix cluster, or an ErrNotConfigured error if it is not configured.
+// The certificates of the CA bundle file of the profile are added to its trust bundle.
+func (n *NutanixClient) Endpoint() (envtypes.ManagementEndpoint, error) {
+	mgmtEndpoint, err := n.env.GetManagementEndpoint(envtypes.Topology{})
+	if err != nil {
+		return envtypes.ManagementEndpoint{}, newError(ErrNotConfigured, n.profile, err)
+	}
+	if mgmtEndpoint == nil || mgmtEndpoint.Address == nil {
+		return envtypes.ManagementEndpoint{}, newError(ErrNotConfigured, n.profile, fmt.Errorf("no endpoint set"))
+	}
+
+	endpoint := *mgmtEndpoint
+	if path := n.setting(caBundleKey); path != "" {
+		// The file is read every time so that a renewed CA is picked up
+		bundle, err := os.ReadFile(path)
+		if err != nil {
+			return envtypes.ManagementEndpoint{}, newError(ErrTLS, n.profile, fmt.Errorf("failed to read CA bundle: %w", err))
+		}
+		endpoint.AdditionalTrustBundle = strings.TrimSpace(endpoint.AdditionalTrustBundle + "\n" + string(bundle))
+	}
+
+	return endpoint, nil
+}
+
+// connection returns the management endpoint with the certificate pin of the profile
+func (n *NutanixClient) connection() (connection, error) {
+	endpoint, err := n.Endpoint()
+	if err != nil {
+		return connection{}, err
+	}
+
+	return connection{ManagementEndpoint: endpoint, Fingerprint: n.setting(fingerprintKey)}, nil
+}
+
+// setting returns a setting of the profile that is not part of its management endpoint, or an empty string
+func (n *NutanixClient) setting(key string) string {
+	value, err := n.env.Get(envtypes.Topology{}, key)
+	if err != nil {
+		return ""
+	}
+	s, _ := value.(string)
+
+	return strings.TrimSpace(s)
 }
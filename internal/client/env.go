@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/credentials"
+
+	envtypes "github.com/nutanix-cloud-native/prism-go-client/environment/types"
+	"k8s.io/klog"
+)
+
+const (
+	envEndpoint    = "NUTANIX_ENDPOINT"
+	envPort        = "NUTANIX_PORT"
+	envInsecure    = "NUTANIX_INSECURE"
+	envTrustBundle = "NUTANIX_ADDITIONAL_TRUST_BUNDLE"
+	envCategories  = "NUTANIX_CATEGORIES"
+	envCABundle    = "NUTANIX_CA_BUNDLE"
+	envFingerprint = "NUTANIX_CERT_FINGERPRINT"
+
+	// credentialsTTL is how long looked up credentials are reused, so that rotated secrets are picked up
+	credentialsTTL = 5 * time.Minute
+)
+
+// EnvProvider is the provider of the default profile reading the NUTANIX_* environment variables.
+// Unlike the local provider of prism-go-client, it looks up the username and password or the API key through the
+// credential chain, e.g. from NUTANIX_PASSWORD_FILE, a netrc file or a credential helper.
+var EnvProvider = &envProvider{chain: credentials.DefaultChain()}
+
+// envProvider implements the Provider interface of prism-go-client
+type envProvider struct {
+	chain credentials.Chain
+
+	mu       sync.Mutex
+	creds    credentials.Credentials
+	expires  time.Time
+	endpoint string
+}
+
+var _ envtypes.Provider = &envProvider{}
+
+// Configured reports whether NUTANIX_ENDPOINT is set and its credentials can be looked up
+func (p *envProvider) Configured() bool {
+	_, err := p.GetManagementEndpoint(envtypes.Topology{})
+	return err == nil
+}
+
+// GetManagementEndpoint implements the Provider interface
+func (p *envProvider) GetManagementEndpoint(envtypes.Topology) (*envtypes.ManagementEndpoint, error) {
+	endpoint := strings.TrimSpace(os.Getenv(envEndpoint))
+	// No local environment defined, fall back to the credentials prompt
+	if endpoint == "" {
+		return nil, envtypes.ErrNotFound
+	}
+	port := strings.TrimSpace(os.Getenv(envPort))
+	if port == "" {
+		port = "9440"
+	}
+	address := fmt.Sprintf("%s:%s", endpoint, port)
+	if !strings.HasPrefix(address, "https://") {
+		address = fmt.Sprintf("https://%s", address)
+	}
+	addr, err := url.Parse(address)
+	if err != nil {
+		return nil, err
+	}
+
+	creds, err := p.credentials(endpoint)
+	if errors.Is(err, credentials.ErrNotFound) {
+		return nil, envtypes.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	apiCredentials := envtypes.ApiCredentials{Username: creds.Username, Password: creds.Password}
+	if creds.APIKey != "" {
+		apiCredentials = envtypes.ApiCredentials{Username: APIKeyUsername, Password: creds.APIKey}
+	}
+
+	return &envtypes.ManagementEndpoint{
+		Address:               addr,
+		ApiCredentials:        apiCredentials,
+		Insecure:              os.Getenv(envInsecure) == "true",
+		AdditionalTrustBundle: os.Getenv(envTrustBundle),
+	}, nil
+}
+
+// Get implements the Provider interface
+func (p *envProvider) Get(_ envtypes.Topology, key string) (interface{}, error) {
+	switch key {
+	case envtypes.CategoriesKey:
+		return strings.Split(os.Getenv(envCategories), ","), nil
+	case caBundleKey:
+		return envValue(envCABundle)
+	case fingerprintKey:
+		return envValue(envFingerprint)
+	}
+
+	return nil, envtypes.ErrNotFound
+}
+
+// envValue returns the value of an environment variable, or ErrNotFound if it is not set
+func envValue(name string) (interface{}, error) {
+	value := strings.TrimSpace(os.Getenv(name))
+	if value == "" {
+		return nil, envtypes.ErrNotFound
+	}
+
+	return value, nil
+}
+
+// credentials looks up the credentials of an endpoint, reusing them until they expire
+func (p *envProvider) credentials(endpoint string) (credentials.Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.endpoint == endpoint && time.Now().Before(p.expires) {
+		return p.creds, nil
+	}
+
+	creds, err := p.chain.Lookup(context.Background(), credentials.Request{
+		Service: "nutanix",
+		Prefix:  "NUTANIX",
+		Host:    endpoint,
+		APIKey:  true,
+	})
+	if err != nil {
+		if !errors.Is(err, credentials.ErrNotFound) {
+			klog.Errorf("failed to look up the credentials of %s: %s", endpoint, err.Error())
+		}
+		return credentials.Credentials{}, err
+	}
+	p.creds, p.expires, p.endpoint = creds, time.Now().Add(credentialsTTL), endpoint
+
+	return creds, nil
+}
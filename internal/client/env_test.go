@@ -0,0 +1,44 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/credentials"
+
+	envtypes "github.com/nutanix-cloud-native/prism-go-client/environment/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvProvider(t *testing.T) {
+	provider := &envProvider{chain: credentials.Chain{credentials.EnvSource{}, credentials.FileSource{}}}
+	t.Setenv("NUTANIX_USERNAME", "admin")
+	t.Setenv("NUTANIX_PASSWORD", "")
+
+	// Without an endpoint the credentials prompt is used
+	t.Setenv("NUTANIX_ENDPOINT", "")
+	_, err := provider.GetManagementEndpoint(envtypes.Topology{})
+	assert.ErrorIs(t, err, envtypes.ErrNotFound)
+
+	// and without a password too
+	t.Setenv("NUTANIX_ENDPOINT", "pc.example.com")
+	assert.False(t, provider.Configured())
+
+	path := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(path, []byte("secret\n"), 0o600))
+	t.Setenv("NUTANIX_PASSWORD_FILE", path)
+
+	endpoint, err := provider.GetManagementEndpoint(envtypes.Topology{})
+	require.NoError(t, err)
+	assert.Equal(t, "pc.example.com:9440", endpoint.Address.Host)
+	assert.Equal(t, envtypes.ApiCredentials{Username: "admin", Password: "secret"}, endpoint.ApiCredentials)
+
+	// An API key replaces the username and password
+	provider = &envProvider{chain: credentials.Chain{credentials.EnvSource{}, credentials.FileSource{}}}
+	t.Setenv("NUTANIX_API_KEY", "key")
+	endpoint, err = provider.GetManagementEndpoint(envtypes.Topology{})
+	require.NoError(t, err)
+	assert.Equal(t, envtypes.ApiCredentials{Username: APIKeyUsername, Password: "key"}, endpoint.ApiCredentials)
+}
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+)
+
+// Kinds of Prism client errors, matched with errors.Is
+var (
+	ErrNotConfigured = errors.New("prism central connection not configured")
+	ErrAuthFailed    = errors.New("prism central authentication failed")
+	ErrUnreachable   = errors.New("prism central endpoint unreachable")
+	ErrTLS           = errors.New("prism central TLS verification failed")
+)
+
+// Error is a recoverable Prism client error of one of the kinds above
+type Error struct {
+	Kind    error
+	Profile string
+	Err     error
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("%s (profile %s)", e.Kind, e.Profile)
+	}
+
+	return fmt.Sprintf("%s (profile %s): %s", e.Kind, e.Profile, e.Err)
+}
+
+// Unwrap returns both the kind and the cause of the error
+func (e *Error) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+
+	return []error{e.Kind, e.Err}
+}
+
+// Hint returns how to remediate the error
+func (e *Error) Hint() string {
+	switch e.Kind {
+	case ErrNotConfigured:
+		if e.Profile != DefaultProfile {
+			return fmt.Sprintf("Set the credentials of profile %s with the credentials prompt and its profile argument, or add the profile to the MCP_PROFILES file.", e.Profile)
+		}
+		return "Set the credentials with the credentials prompt, or start the server with NUTANIX_ENDPOINT and either NUTANIX_USERNAME and NUTANIX_PASSWORD or NUTANIX_API_KEY."
+	case ErrAuthFailed:
+		return "Check the username and password or the API key, then set the credentials again with the credentials prompt. The account may also be locked or lack the permissions for this API."
+	case ErrUnreachable:
+		return "Check the endpoint and port (default 9440) and that Prism Central is reachable from the machine running this server."
+	case ErrTLS:
+		return "Trust the CA of the presented chain with ca_bundle or NUTANIX_CA_BUNDLE, or pin the SHA-256 fingerprint of the Prism Central certificate with cert_fingerprint or NUTANIX_CERT_FINGERPRINT. Set insecure to true only for lab environments."
+	}
+
+	return ""
+}
+
+// newError returns a Prism client error of a kind
+func newError(kind error, profile string, err error) *Error {
+	if profile == "" {
+		profile = DefaultProfile
+	}
+
+	return &Error{Kind: kind, Profile: profile, Err: err}
+}
+
+// Classify turns an error returned while talking to Prism Central into a typed error of the connection profile.
+// Errors that are already typed or of no known kind are returned unchanged.
+func Classify(profile string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var clientErr *Error
+	if errors.As(err, &clientErr) {
+		return err
+	}
+	if kind := errorKind(err); kind != nil {
+		return newError(kind, profile, err)
+	}
+
+	return err
+}
+
+// errorKind returns the kind of an error of the HTTP clients, or nil if it is of no known kind
+func errorKind(err error) error {
+	// TLS errors are network errors too, so check them first
+	var (
+		unknownAuthority x509.UnknownAuthorityError
+		hostname         x509.HostnameError
+		invalid          x509.CertificateInvalidError
+		verification     *tls.CertificateVerificationError
+		recordHeader     tls.RecordHeaderError
+		certificate      *CertificateError
+	)
+	if errors.As(err, &unknownAuthority) || errors.As(err, &hostname) || errors.As(err, &invalid) ||
+		errors.As(err, &verification) || errors.As(err, &recordHeader) || errors.As(err, &certificate) {
+		return ErrTLS
+	}
+
+	if status := httpStatus(err); status == "401" || status == "403" {
+		return ErrAuthFailed
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return ErrUnreachable
+	}
+
+	// prism-go-client formats some errors with %s, which loses the error chain
+	message := err.Error()
+	switch {
+	case strings.Contains(message, "invalid Nutanix credentials"),
+		strings.Contains(message, "401 Unauthorized"),
+		strings.Contains(message, "403 Forbidden"):
+		return ErrAuthFailed
+	case strings.Contains(message, "x509: "),
+		strings.Contains(message, "tls: "):
+		return ErrTLS
+	case strings.Contains(message, "connection refused"),
+		strings.Contains(message, "no such host"),
+		strings.Contains(message, "i/o timeout"),
+		strings.Contains(message, "network is unreachable"):
+		return ErrUnreachable
+	}
+
+	return nil
+}
+
+// httpStatus returns the status code of the GenericOpenAPIError of the v4 clients in an error chain.
+// Every v4 client declares its own GenericOpenAPIError type, so they are matched by their Status field.
+func httpStatus(err error) string {
+	for ; err != nil; err = errors.Unwrap(err) {
+		v := reflect.Indirect(reflect.ValueOf(err))
+		if v.Kind() != reflect.Struct || !strings.HasSuffix(v.Type().Name(), "OpenAPIError") {
+			continue
+		}
+		if status := v.FieldByName("Status"); status.IsValid() && status.Kind() == reflect.String {
+			code, _, _ := strings.Cut(status.String(), " ")
+			return code
+		}
+	}
+
+	return ""
+}
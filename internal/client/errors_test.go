@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// initTestProfile initializes a connection profile pointing at a test server
+func initTestProfile(t *testing.T, name, serverURL string, insecure bool) *NutanixClient {
+	u, err := url.Parse(serverURL)
+	require.NoError(t, err)
+
+	InitProfile(name, NewMCPModelContextClient(map[string]string{
+		"endpoint": u.Hostname(),
+		"port":     u.Port(),
+		"username": "admin",
+		"password": "secret",
+		"insecure": fmt.Sprint(insecure),
+	}))
+	prismClient, err := LookupProfile(name)
+	require.NoError(t, err)
+
+	return prismClient
+}
+
+func TestClientErrors(t *testing.T) {
+	unauthorized := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer unauthorized.Close()
+
+	// prism-go-client shares http.DefaultTransport, so use a server without pooled connections for the TLS failure
+	selfSigned := httptest.NewTLSServer(http.NotFoundHandler())
+	defer selfSigned.Close()
+
+	// A closed server refuses connections
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	tests := []struct {
+		name      string
+		serverURL string
+		insecure  bool
+		kind      error
+	}{
+		{name: "errors-auth", serverURL: unauthorized.URL, insecure: true, kind: ErrAuthFailed},
+		{name: "errors-tls", serverURL: selfSigned.URL, kind: ErrTLS},
+		{name: "errors-unreachable", serverURL: closed.URL, insecure: true, kind: ErrUnreachable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prismClient := initTestProfile(t, tt.name, tt.serverURL, tt.insecure)
+
+			// Basic credentials log in when the client is created, so the error may come from either
+			v3Client, err := prismClient.V3()
+			if err == nil {
+				_, err = v3Client.GetPrismCentral(context.Background())
+			}
+			require.Error(t, err)
+
+			err = Classify(prismClient.Profile(), err)
+			assert.ErrorIs(t, err, tt.kind)
+
+			var clientErr *Error
+			require.True(t, errors.As(err, &clientErr))
+			assert.Equal(t, tt.name, clientErr.Profile)
+			assert.NotEmpty(t, clientErr.Hint())
+		})
+	}
+}
+
+func TestNotConfiguredErrors(t *testing.T) {
+	// A profile without an endpoint fails instead of panicking
+	InitProfile("errors-empty", NewMCPModelContextClient(map[string]string{}))
+	prismClient, err := LookupProfile("errors-empty")
+	require.NoError(t, err)
+
+	_, err = prismClient.V3()
+	assert.ErrorIs(t, err, ErrNotConfigured)
+	_, err = prismClient.V4()
+	assert.ErrorIs(t, err, ErrNotConfigured)
+	_, err = prismClient.Get(context.Background(), "/api/clustermgmt/v4.0/config/clusters", nil)
+	assert.ErrorIs(t, err, ErrNotConfigured)
+
+	_, err = LookupProfile("errors-missing")
+	assert.ErrorIs(t, err, ErrNotConfigured)
+	assert.ErrorContains(t, err, `unknown profile "errors-missing"`)
+
+	// Errors of no known kind are returned unchanged
+	other := errors.New("entity not found")
+	assert.Equal(t, other, Classify("errors-empty", other))
+	assert.Nil(t, Classify("errors-empty", nil))
+}
@@ -0,0 +1,192 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+
+	envtypes "github.com/nutanix-cloud-native/prism-go-client/environment/types"
+	"gopkg.in/yaml.v3"
+)
+
+// profileNamePattern restricts profile names to what is safe in tool arguments and resource URIs
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ProfileConfig holds the connection settings of a named Prism Central profile
+type ProfileConfig struct {
+	Endpoint    string `json:"endpoint" yaml:"endpoint"`
+	Port        int    `json:"port,omitempty" yaml:"port,omitempty"`
+	Username    string `json:"username" yaml:"username"`
+	Password    string `json:"password" yaml:"password"`
+	APIKey      string `json:"api_key,omitempty" yaml:"api_key,omitempty"`
+	Insecure    bool   `json:"insecure,omitempty" yaml:"insecure,omitempty"`
+	TrustBundle string `json:"trust_bundle,omitempty" yaml:"trust_bundle,omitempty"`
+	// CABundle is the path of a PEM file of CA certificates trusted in addition to the system roots
+	CABundle string `json:"ca_bundle,omitempty" yaml:"ca_bundle,omitempty"`
+	// CertFingerprint is the SHA-256 fingerprint the certificate of Prism Central has to match
+	CertFingerprint string `json:"cert_fingerprint,omitempty" yaml:"cert_fingerprint,omitempty"`
+}
+
+// ProfilesConfig is the content of a connection profiles file
+type ProfilesConfig struct {
+	Profiles map[string]ProfileConfig `json:"profiles" yaml:"profiles"`
+}
+
+// Statuses of a connection profile reported by Profiles
+const (
+	ProfileConfigured    = "configured"
+	ProfileNotConfigured = "not configured"
+	ProfileInvalid       = "invalid"
+)
+
+// ProfileInfo describes a configured connection profile, without its credentials
+type ProfileInfo struct {
+	Name            string `json:"name"`
+	Status          string `json:"status"`
+	Error           string `json:"error,omitempty"`
+	Endpoint        string `json:"endpoint,omitempty"`
+	Username        string `json:"username,omitempty"`
+	Auth            string `json:"auth,omitempty"`
+	Insecure        bool   `json:"insecure"`
+	CABundle        string `json:"ca_bundle,omitempty"`
+	CertFingerprint string `json:"cert_fingerprint,omitempty"`
+	Default         bool   `json:"default"`
+}
+
+// ValidateProfileName checks that a profile name only holds letters, digits, '_' and '-'
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: only letters, digits, '_' and '-' are allowed", name)
+	}
+	return nil
+}
+
+// LoadProfiles reads named connection profiles from a YAML or JSON file such as
+//
+//	profiles:
+//	  prod:
+//	    endpoint: pc.prod.example.com
+//	    username: admin
+//	    password: secret
+//	  lab:
+//	    endpoint: 10.0.0.10
+//	    username: admin
+//	    password: secret
+//	    insecure: true
+//	  internal:
+//	    endpoint: pc.corp.example.com
+//	    username: admin
+//	    password: secret
+//	    ca_bundle: /etc/ssl/corp-ca.pem
+//	    cert_fingerprint: 5E:3C:...:9A
+//	  automation:
+//	    endpoint: pc.prod.example.com
+//	    api_key: key
+//
+// and initializes a client for each of them. A profile named default replaces the default profile.
+func LoadProfiles(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read connection profiles: %w", err)
+	}
+
+	config := ProfilesConfig{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("invalid connection profiles in %s: %w", path, err)
+	}
+
+	// Validate every profile before initializing any
+	for name, profile := range config.Profiles {
+		if err := ValidateProfileName(name); err != nil {
+			return err
+		}
+		if profile.Endpoint == "" {
+			return fmt.Errorf("profile %s: endpoint is required", name)
+		}
+		if _, err := APICredentials(profile.Username, profile.Password, profile.APIKey); err != nil {
+			return fmt.Errorf("profile %s: %w", name, err)
+		}
+		if profile.CertFingerprint != "" {
+			if err := ValidateFingerprint(profile.CertFingerprint); err != nil {
+				return fmt.Errorf("profile %s: %w", name, err)
+			}
+		}
+	}
+
+	for name, profile := range config.Profiles {
+		InitProfile(name, NewMCPModelContextClient(profile.values()))
+	}
+
+	return nil
+}
+
+// values returns the settings in the keys read by the model context provider of prism-go-client
+func (p ProfileConfig) values() map[string]string {
+	creds := envtypes.ApiCredentials{Username: p.Username, Password: p.Password}
+	if p.APIKey != "" {
+		creds = envtypes.ApiCredentials{Username: APIKeyUsername, Password: p.APIKey}
+	}
+	values := map[string]string{
+		"endpoint":     p.Endpoint,
+		"username":     creds.Username,
+		"password":     creds.Password,
+		"insecure":     strconv.FormatBool(p.Insecure),
+		"trustBundle":  p.TrustBundle,
+		caBundleKey:    p.CABundle,
+		fingerprintKey: p.CertFingerprint,
+	}
+	if p.Port != 0 {
+		values["port"] = strconv.Itoa(p.Port)
+	}
+
+	return values
+}
+
+// Profiles describes the connection profiles, sorted by name.
+// Profiles without credentials yet, such as the default profile before the credentials prompt, are reported as not configured.
+func Profiles() []ProfileInfo {
+	profilesMu.RLock()
+	clients := make([]*NutanixClient, 0, len(profiles))
+	for _, c := range profiles {
+		clients = append(clients, c)
+	}
+	profilesMu.RUnlock()
+
+	infos := make([]ProfileInfo, 0, len(clients))
+	for _, c := range clients {
+		info := ProfileInfo{Name: c.profile, Status: ProfileConfigured, Default: c.profile == DefaultProfile}
+		endpoint, err := c.Endpoint()
+		switch {
+		case errors.Is(err, ErrNotConfigured):
+			info.Status = ProfileNotConfigured
+			infos = append(infos, info)
+			continue
+		case err != nil:
+			// e.g. an unreadable CA bundle, the other settings are still shown
+			info.Status, info.Error = ProfileInvalid, err.Error()
+			if raw, rawErr := c.env.GetManagementEndpoint(envtypes.Topology{}); rawErr == nil && raw != nil {
+				endpoint = *raw
+			}
+		}
+		if endpoint.Address != nil {
+			info.Endpoint = endpoint.Address.Host
+		}
+		switch {
+		case apiKey(endpoint) != "":
+			info.Auth = "api_key"
+		case endpoint.Username != "":
+			info.Auth = "basic"
+			info.Username = endpoint.Username
+		}
+		info.Insecure = endpoint.Insecure
+		info.CABundle = c.setting(caBundleKey)
+		info.CertFingerprint = c.setting(fingerprintKey)
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+
+	return infos
+}
@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadProfiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+profiles:
+  prod:
+    endpoint: pc.prod.example.com
+    username: admin
+    password: secret
+  lab:
+    endpoint: 10.0.0.10
+    port: 9441
+    username: lab
+    password: secret
+    insecure: true
+  automation:
+    endpoint: pc.prod.example.com
+    api_key: key
+`), 0o600))
+	require.NoError(t, LoadProfiles(path))
+
+	prod, err := LookupProfile("prod")
+	require.NoError(t, err)
+	lab, err := LookupProfile("lab")
+	require.NoError(t, err)
+
+	// Every profile has its own client cache entry
+	assert.NotEqual(t, prod.Key(), lab.Key())
+	assert.Equal(t, "lab", lab.Profile())
+	assert.Equal(t, "10.0.0.10:9441", lab.ManagementEndpoint().Address.Host)
+
+	infos := map[string]ProfileInfo{}
+	for _, info := range Profiles() {
+		infos[info.Name] = info
+	}
+	assert.Equal(t, ProfileInfo{Name: "lab", Status: ProfileConfigured, Endpoint: "10.0.0.10:9441", Username: "lab", Auth: "basic", Insecure: true}, infos["lab"])
+	assert.Equal(t, "pc.prod.example.com:9440", infos["prod"].Endpoint)
+	// API keys are not shown as usernames
+	assert.Equal(t, ProfileInfo{Name: "automation", Status: ProfileConfigured, Endpoint: "pc.prod.example.com:9440", Auth: "api_key"}, infos["automation"])
+
+	_, err = LookupProfile("dr")
+	assert.ErrorContains(t, err, `unknown profile "dr", available:`)
+
+	// Loading a profile again replaces it
+	assert.True(t, InitProfile("lab", NewMCPModelContextClient(map[string]string{"endpoint": "10.0.0.11"})))
+	lab, err = LookupProfile("lab")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.11:9440", lab.ManagementEndpoint().Address.Host)
+}
+
+func TestProfilesStatus(t *testing.T) {
+	// A profile without credentials yet is reported, not logged as an error
+	InitProfile("status-empty", NewMCPModelContextClient(nil))
+	InitProfile("status-ca", NewMCPModelContextClient(map[string]string{
+		"endpoint":  "pc.example.com",
+		"username":  "admin",
+		"password":  "secret",
+		caBundleKey: filepath.Join(t.TempDir(), "missing.pem"),
+	}))
+
+	infos := map[string]ProfileInfo{}
+	for _, info := range Profiles() {
+		infos[info.Name] = info
+	}
+	assert.Equal(t, ProfileInfo{Name: "status-empty", Status: ProfileNotConfigured}, infos["status-empty"])
+
+	invalid := infos["status-ca"]
+	assert.Equal(t, ProfileInvalid, invalid.Status)
+	assert.Contains(t, invalid.Error, "failed to read CA bundle")
+	assert.Equal(t, "pc.example.com:9440", invalid.Endpoint)
+	assert.Equal(t, "admin", invalid.Username)
+}
+
+func TestLoadProfilesRejectsInvalidProfiles(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"name.yaml":        "profiles:\n  \"prod central\":\n    endpoint: pc\n",
+		"endpoint.yaml":    "profiles:\n  prod:\n    username: admin\n    password: secret\n",
+		"password.yaml":    "profiles:\n  prod:\n    endpoint: pc\n    username: admin\n",
+		"api_key.yaml":     "profiles:\n  prod:\n    endpoint: pc\n    username: admin\n    password: secret\n    api_key: key\n",
+		"fingerprint.yaml": "profiles:\n  prod:\n    endpoint: pc\n    api_key: key\n    cert_fingerprint: 5E:3C\n",
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		assert.Error(t, LoadProfiles(path), name)
+	}
+}
+
+func TestInitCheckedProfile(t *testing.T) {
+	// A test Prism Central only accepting the password secret, and the session cookie it returns
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, password, ok := r.BasicAuth(); ok && password == "secret" {
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "checked", Path: "/"})
+		} else if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "checked" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	prismClient := initTestProfile(t, "checked", server.URL, true)
+	check := func(candidate *NutanixClient) error {
+		v3Client, err := candidate.V3()
+		if err == nil {
+			_, err = v3Client.GetPrismCentral(context.Background())
+		}
+		return err
+	}
+	require.NoError(t, check(prismClient))
+
+	// Wrong credentials don't replace the profile, which keeps working
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	wrong := NewMCPModelContextClient(map[string]string{
+		"endpoint": u.Hostname(),
+		"port":     u.Port(),
+		"username": "admin",
+		"password": "wrong",
+		"insecure": "true",
+	})
+	replaced, err := InitCheckedProfile("checked", wrong, check)
+	require.Error(t, err)
+	assert.False(t, replaced)
+	current, err := LookupProfile("checked")
+	require.NoError(t, err)
+	assert.Same(t, prismClient, current)
+	assert.NoError(t, check(current))
+
+	// Credentials passing the check replace the profile
+	replaced, err = InitCheckedProfile("checked", NewMCPModelContextClient(map[string]string{
+		"endpoint": u.Hostname(),
+		"port":     u.Port(),
+		"username": "other",
+		"password": "secret",
+		"insecure": "true",
+	}), check)
+	require.NoError(t, err)
+	assert.True(t, replaced)
+	current, err = LookupProfile("checked")
+	require.NoError(t, err)
+	assert.Equal(t, "other", current.ManagementEndpoint().Username)
+	assert.NoError(t, check(current))
+}
@@ -0,0 +1,224 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	envtypes "github.com/nutanix-cloud-native/prism-go-client/environment/types"
+	prismclientv4 "github.com/nutanix-cloud-native/prism-go-client/v4"
+)
+
+const (
+	restTimeout      = 60 * time.Second
+	maxErrorBodySize = 4096
+)
+
+// restSession holds the transport shared by the clients of a profile, and the HTTP client of its REST calls,
+// whose cookie jar keeps the session cookie so that basic credentials are only sent to log in
+type restSession struct {
+	mu     sync.Mutex
+	hash   string
+	base   *http.Transport
+	client *http.Client
+	jar    *sessionJar
+}
+
+// sessionJar is a cookie jar that is cleared when the session expires
+type sessionJar struct {
+	mu  sync.Mutex
+	jar http.CookieJar
+}
+
+// SetCookies implements the CookieJar interface
+func (j *sessionJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.jar.SetCookies(u, cookies)
+}
+
+// Cookies implements the CookieJar interface
+func (j *sessionJar) Cookies(u *url.URL) []*http.Cookie {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.jar.Cookies(u)
+}
+
+// clear drops the session cookie
+func (j *sessionJar) clear() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.jar, _ = cookiejar.New(nil)
+}
+
+// transport returns the transport of a connection, creating it when the connection changed
+func (s *restSession) transport(conn connection) (*http.Transport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.update(conn); err != nil {
+		return nil, err
+	}
+
+	return s.base, nil
+}
+
+// httpClient returns the HTTP client of a connection, creating it when the connection changed
+func (s *restSession) httpClient(conn connection) (*http.Client, *sessionJar, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.update(conn); err != nil {
+		return nil, nil, err
+	}
+
+	return s.client, s.jar, nil
+}
+
+// update creates the transport and HTTP client of a connection unless they are current
+func (s *restSession) update(conn connection) error {
+	hash := endpointHash(conn)
+	if s.client != nil && s.hash == hash {
+		return nil
+	}
+
+	transport, err := newTransport(conn)
+	if err != nil {
+		return err
+	}
+	jar := &sessionJar{}
+	jar.clear()
+
+	if s.base != nil {
+		s.base.CloseIdleConnections()
+	}
+	s.hash = hash
+	s.base = transport
+	s.jar = jar
+	s.client = &http.Client{
+		Transport: transport,
+		Jar:       jar,
+		Timeout:   restTimeout,
+	}
+
+	return nil
+}
+
+// Get performs a read-only GET request against a Prism Central API path,
+// e.g. /api/vmm/v4.0/ahv/config/vms, and decodes the JSON response
+func (n *NutanixClient) Get(ctx context.Context, path string, query url.Values) (interface{}, error) {
+	var result interface{}
+	if err := n.getJSON(ctx, path, query, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// VersionRoutes returns the API namespaces, versions and routes advertised by the actuator API of Prism Central.
+// Unlike the actuator API of prism-go-client, it supports API keys.
+func (n *NutanixClient) VersionRoutes(ctx context.Context) ([]prismclientv4.ActuatorNamespaceVersionRoutes, error) {
+	var routes []prismclientv4.ActuatorNamespaceVersionRoutes
+	if err := n.getJSON(ctx, "/api/actuator/versionroutes", nil, &routes); err != nil {
+		return nil, err
+	}
+
+	return routes, nil
+}
+
+// getJSON performs a GET request and decodes the JSON response into v
+func (n *NutanixClient) getJSON(ctx context.Context, path string, query url.Values, v interface{}) error {
+	conn, err := n.connection()
+	if err != nil {
+		return err
+	}
+
+	httpClient, jar, err := n.rest.httpClient(conn)
+	if err != nil {
+		return newError(ErrTLS, n.profile, err)
+	}
+
+	reqURL, err := requestURL(conn.Address, path, query)
+	if err != nil {
+		return err
+	}
+	resp, err := n.doGet(ctx, httpClient, jar, conn.ManagementEndpoint, reqURL)
+	if err != nil {
+		return Classify(n.profile, fmt.Errorf("GET %s failed: %w", path, err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return Classify(n.profile, fmt.Errorf("GET %s returned %s: %s", path, resp.Status, string(body)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response of GET %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// requestURL appends an already escaped API path to the endpoint address. The path is
+// used verbatim so escaped segments are not escaped twice, and dot segments are
+// rejected rather than resolved so a path can not step outside the requested route
+func requestURL(base *url.URL, path string, query url.Values) (*url.URL, error) {
+	for _, segment := range strings.Split(path, "/") {
+		if segment, err := url.PathUnescape(segment); err == nil && (segment == "." || segment == "..") {
+			return nil, fmt.Errorf("invalid path %s: dot segments are not allowed", path)
+		}
+	}
+	unescaped, err := url.PathUnescape(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path %s: %w", path, err)
+	}
+
+	reqURL := *base
+	reqURL.Path = strings.TrimSuffix(base.Path, "/") + unescaped
+	reqURL.RawPath = strings.TrimSuffix(base.EscapedPath(), "/") + path
+	reqURL.RawQuery = query.Encode()
+
+	return &reqURL, nil
+}
+
+// doGet sends a GET request authenticated with the API key, the session cookie or the basic credentials.
+// When the session cookie was rejected, it logs in again once.
+func (n *NutanixClient) doGet(ctx context.Context, httpClient *http.Client, jar *sessionJar, endpoint envtypes.ManagementEndpoint, reqURL *url.URL) (*http.Response, error) {
+	key := apiKey(endpoint)
+	session := key == "" && len(jar.Cookies(reqURL)) > 0
+
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
+		req.Header.Set("Accept", "application/json")
+		switch {
+		case key != "":
+			req.Header.Set(apiKeyHeader, key)
+		case !session:
+			req.SetBasicAuth(endpoint.Username, endpoint.Password)
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if !session || resp.StatusCode != http.StatusUnauthorized {
+			return resp, nil
+		}
+
+		// The session expired, log in with the basic credentials again
+		resp.Body.Close()
+		jar.clear()
+		session = false
+	}
+}
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestURL(t *testing.T) {
+	base, err := url.Parse("https://prism.example.com:9440")
+	require.NoError(t, err)
+
+	reqURL, err := requestURL(base, "/api/vmm/v4.0/ahv/config/vms/a%20b", url.Values{"$limit": {"1"}})
+	require.NoError(t, err)
+	assert.Equal(t, "https://prism.example.com:9440/api/vmm/v4.0/ahv/config/vms/a%20b?%24limit=1", reqURL.String())
+	assert.Equal(t, "/api/vmm/v4.0/ahv/config/vms/a b", reqURL.Path)
+
+	_, err = requestURL(base, "/api/vmm/v4.0/ahv/config/vms/../../../clustermgmt/v4.0/config/clusters", nil)
+	assert.Error(t, err)
+	_, err = requestURL(base, "/api/vmm/v4.0/ahv/config/vms/.", nil)
+	assert.Error(t, err)
+	_, err = requestURL(base, "/api/vmm/v4.0/ahv/config/vms/%2E%2E", nil)
+	assert.Error(t, err)
+
+	// an escaped slash stays part of its segment
+	reqURL, err = requestURL(base, "/api/vmm/v4.0/ahv/config/vms/a%2Fb", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "https://prism.example.com:9440/api/vmm/v4.0/ahv/config/vms/a%2Fb", reqURL.String())
+}
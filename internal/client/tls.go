@@ -0,0 +1,149 @@
+package client
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	envtypes "github.com/nutanix-cloud-native/prism-go-client/environment/types"
+)
+
+const (
+	// caBundleKey is the environment key of the path of a PEM encoded CA bundle trusted in addition to the system roots
+	caBundleKey = "caBundle"
+	// fingerprintKey is the environment key of the SHA-256 fingerprint the certificate of Prism Central has to match
+	fingerprintKey = "certFingerprint"
+)
+
+// connection is the management endpoint of a profile with the certificate pin, which prism-go-client doesn't know
+type connection struct {
+	envtypes.ManagementEndpoint
+	Fingerprint string
+}
+
+// CertificateError is a certificate of Prism Central that failed verification, with the presented certificate chain
+type CertificateError struct {
+	Err   error
+	Chain []*x509.Certificate
+}
+
+// Error implements the error interface
+func (e *CertificateError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "tls: %s; presented certificate chain:", e.Err)
+	for i, cert := range e.Chain {
+		fmt.Fprintf(&b, "\n  %d: subject %q, issuer %q, valid %s to %s, SHA-256 %s", i, cert.Subject, cert.Issuer,
+			cert.NotBefore.UTC().Format(time.DateOnly), cert.NotAfter.UTC().Format(time.DateOnly), Fingerprint(cert))
+	}
+
+	return b.String()
+}
+
+// Unwrap returns the verification error
+func (e *CertificateError) Unwrap() error {
+	return e.Err
+}
+
+// Fingerprint returns the SHA-256 fingerprint of a certificate, formatted like openssl x509 -fingerprint -sha256
+func Fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+
+	return strings.Join(parts, ":")
+}
+
+// ValidateFingerprint checks that a certificate pin is a SHA-256 fingerprint, with or without colons
+func ValidateFingerprint(pin string) error {
+	_, err := normalizeFingerprint(pin)
+	return err
+}
+
+// normalizeFingerprint returns a SHA-256 fingerprint as lowercase hex without separators
+func normalizeFingerprint(pin string) (string, error) {
+	normalized := strings.ToLower(strings.NewReplacer(":", "", " ", "").Replace(strings.TrimSpace(pin)))
+	if decoded, err := hex.DecodeString(normalized); err != nil || len(decoded) != sha256.Size {
+		return "", fmt.Errorf("invalid certificate fingerprint %q: expected 32 hex encoded bytes of a SHA-256 fingerprint", pin)
+	}
+
+	return normalized, nil
+}
+
+// newTransport returns an HTTP transport honouring the TLS settings of a connection.
+// It verifies the certificate itself, so that failures report the presented chain and pinned certificates can be checked.
+func newTransport(conn connection) (*http.Transport, error) {
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	if conn.AdditionalTrustBundle != "" && !roots.AppendCertsFromPEM([]byte(conn.AdditionalTrustBundle)) {
+		return nil, fmt.Errorf("additional trust bundle contains no valid PEM certificates")
+	}
+
+	var pin string
+	if conn.Fingerprint != "" {
+		if pin, err = normalizeFingerprint(conn.Fingerprint); err != nil {
+			return nil, err
+		}
+	}
+
+	host := conn.Address.Hostname()
+	insecure := conn.Insecure
+	tlsConfig := &tls.Config{
+		// Verification is done by VerifyConnection
+		InsecureSkipVerify: true,
+	}
+	if !insecure || pin != "" {
+		tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			return verifyCertificate(state.PeerCertificates, host, roots, insecure, pin)
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
+
+// verifyCertificate verifies the certificate chain presented by a host unless insecure, and the certificate pin if any
+func verifyCertificate(chain []*x509.Certificate, host string, roots *x509.CertPool, insecure bool, pin string) error {
+	if len(chain) == 0 {
+		return fmt.Errorf("tls: no certificate presented")
+	}
+
+	if !insecure {
+		intermediates := x509.NewCertPool()
+		for _, cert := range chain[1:] {
+			intermediates.AddCert(cert)
+		}
+		_, err := chain[0].Verify(x509.VerifyOptions{DNSName: host, Roots: roots, Intermediates: intermediates})
+		if err != nil {
+			return &CertificateError{Err: err, Chain: chain}
+		}
+	}
+
+	if pin != "" {
+		sum := sha256.Sum256(chain[0].Raw)
+		if hex.EncodeToString(sum[:]) != pin {
+			return &CertificateError{Err: fmt.Errorf("certificate fingerprint does not match the pinned fingerprint"), Chain: chain}
+		}
+	}
+
+	return nil
+}
+
+// endpointHash identifies the address, credentials and TLS settings of a connection, to detect changes
+func endpointHash(conn connection) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\x00%s\x00%s\x00%t\x00%s\x00%s", conn.Address, conn.Username, conn.Password,
+		conn.Insecure, conn.AdditionalTrustBundle, conn.Fingerprint)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// initTLSProfile initializes a connection profile pointing at a test server with TLS settings
+func initTLSProfile(t *testing.T, name, serverURL string, settings map[string]string) *NutanixClient {
+	u, err := url.Parse(serverURL)
+	require.NoError(t, err)
+
+	values := map[string]string{
+		"endpoint": u.Hostname(),
+		"port":     u.Port(),
+		"username": "admin",
+		"password": "secret",
+	}
+	for key, value := range settings {
+		values[key] = value
+	}
+	InitProfile(name, NewMCPModelContextClient(values))
+	prismClient, err := LookupProfile(name)
+	require.NoError(t, err)
+
+	return prismClient
+}
+
+// callAll calls Prism Central through the v3, REST and v4 clients of a profile
+func callAll(prismClient *NutanixClient) []error {
+	var errs []error
+
+	v3Client, err := prismClient.V3()
+	if err == nil {
+		_, err = v3Client.GetPrismCentral(context.Background())
+	}
+	errs = append(errs, err)
+
+	_, err = prismClient.Get(context.Background(), "/api/clustermgmt/v4.0/config/clusters", nil)
+	errs = append(errs, err)
+
+	v4Client, err := prismClient.V4()
+	if err == nil {
+		_, err = v4Client.ClustersApiInstance.ListClusters(nil, nil, nil, nil, nil, nil)
+	}
+	errs = append(errs, err)
+
+	return errs
+}
+
+func TestCertificateVerification(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	caBundle := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caBundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600))
+	fingerprint := Fingerprint(server.Certificate())
+	otherFingerprint := strings.Repeat("00:", 31) + "00"
+
+	tests := []struct {
+		name     string
+		settings map[string]string
+		errors   string
+	}{
+		{name: "tls-untrusted", errors: "certificate signed by unknown authority"},
+		{name: "tls-ca-bundle", settings: map[string]string{caBundleKey: caBundle}},
+		{name: "tls-ca-bundle-pinned", settings: map[string]string{caBundleKey: caBundle, fingerprintKey: fingerprint}},
+		{name: "tls-ca-bundle-mismatch", settings: map[string]string{caBundleKey: caBundle, fingerprintKey: otherFingerprint}, errors: "does not match the pinned fingerprint"},
+		// A pin also secures connections that skip the chain verification
+		{name: "tls-insecure-pinned", settings: map[string]string{"insecure": "true", fingerprintKey: strings.ReplaceAll(strings.ToLower(fingerprint), ":", "")}},
+		{name: "tls-insecure-mismatch", settings: map[string]string{"insecure": "true", fingerprintKey: otherFingerprint}, errors: "does not match the pinned fingerprint"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prismClient := initTLSProfile(t, tt.name, server.URL, tt.settings)
+
+			for i, err := range callAll(prismClient) {
+				if tt.errors == "" {
+					assert.NoError(t, err, "call %d", i)
+					continue
+				}
+
+				require.Error(t, err, "call %d", i)
+				err = Classify(prismClient.Profile(), err)
+				assert.ErrorIs(t, err, ErrTLS, "call %d", i)
+				assert.ErrorContains(t, err, tt.errors, "call %d", i)
+				// The error shows the presented chain
+				assert.ErrorContains(t, err, "presented certificate chain:", "call %d", i)
+				assert.ErrorContains(t, err, "SHA-256 "+fingerprint, "call %d", i)
+			}
+		})
+	}
+
+	// The chain stays inspectable for callers that unwrap the error
+	prismClient := initTLSProfile(t, "tls-chain", server.URL, nil)
+	_, err := prismClient.Get(context.Background(), "/api/clustermgmt/v4.0/config/clusters", nil)
+	var certErr *CertificateError
+	require.True(t, errors.As(err, &certErr))
+	require.Len(t, certErr.Chain, 1)
+	assert.Equal(t, server.Certificate().Raw, certErr.Chain[0].Raw)
+}
+
+func TestCABundleErrors(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	prismClient := initTLSProfile(t, "tls-missing-bundle", server.URL, map[string]string{caBundleKey: filepath.Join(t.TempDir(), "missing.pem")})
+	_, err := prismClient.V3()
+	assert.ErrorIs(t, err, ErrTLS)
+	assert.ErrorContains(t, err, "failed to read CA bundle")
+
+	prismClient = initTLSProfile(t, "tls-invalid-pin", server.URL, map[string]string{fingerprintKey: "5E:3C"})
+	_, err = prismClient.V4()
+	assert.ErrorIs(t, err, ErrTLS)
+	assert.ErrorContains(t, err, "invalid certificate fingerprint")
+}
+
+func TestValidateFingerprint(t *testing.T) {
+	hexFingerprint := strings.Repeat("ab", 32)
+	for _, pin := range []string{hexFingerprint, strings.ToUpper(hexFingerprint), strings.Repeat("AB:", 31) + "AB", " " + hexFingerprint + "\n"} {
+		assert.NoError(t, ValidateFingerprint(pin), pin)
+	}
+	for _, pin := range []string{"", "ab", strings.Repeat("ab", 20), strings.Repeat("zz", 32)} {
+		assert.Error(t, ValidateFingerprint(pin), fmt.Sprintf("%q", pin))
+	}
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"github.com/hashicorp/go-retryablehttp"
+	prismclientv4 "github.com/nutanix-cloud-native/prism-go-client/v4"
+	clusterApi "github.com/nutanix/ntnx-api-golang-clients/clustermgmt-go-client/v4/api"
+	clusterClient "github.com/nutanix/ntnx-api-golang-clients/clustermgmt-go-client/v4/client"
+	networkingApi "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/api"
+	networkingClient "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/client"
+	prismApi "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/api"
+	prismClient "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/client"
+	storageApi "github.com/nutanix/ntnx-api-golang-clients/storage-go-client/v4/api"
+	storageClient "github.com/nutanix/ntnx-api-golang-clients/storage-go-client/v4/client"
+	vmApi "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/api"
+	vmClient "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/client"
+	volumesApi "github.com/nutanix/ntnx-api-golang-clients/volumes-go-client/v4/api"
+	volumesClient "github.com/nutanix/ntnx-api-golang-clients/volumes-go-client/v4/client"
+)
+
+var (
+	v4ClientsMu sync.Mutex
+	// v4Clients caches the v4 clients of the profiles by client key, see cachedV4Client
+	v4Clients = map[string]cachedV4{}
+)
+
+// cachedV4 is a cached v4 client with the hash of the connection it was created for
+type cachedV4 struct {
+	hash   string
+	client *prismclientv4.Client
+}
+
+// v4APIClient is the API client of a v4 SDK package
+type v4APIClient interface {
+	AddDefaultHeader(headerName string, headerValue string)
+}
+
+// cachedV4Client returns the v4 client of a profile, creating it when the connection changed.
+// The v4 clients of prism-go-client only verify certificates against the system roots and add basic credentials
+// to every request, so the clients are created here with the transport of the profile.
+func cachedV4Client(n *NutanixClient, conn connection) (*prismclientv4.Client, error) {
+	hash := endpointHash(conn)
+
+	v4ClientsMu.Lock()
+	defer v4ClientsMu.Unlock()
+
+	if cached, ok := v4Clients[n.Key()]; ok && cached.hash == hash {
+		return cached.client, nil
+	}
+
+	transport, err := n.rest.transport(conn)
+	if err != nil {
+		return nil, newError(ErrTLS, n.profile, err)
+	}
+	c, err := newV4Client(conn, transport)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create v4 client: %w", err)
+	}
+	v4Clients[n.Key()] = cachedV4{hash: hash, client: c}
+
+	return c, nil
+}
+
+// deleteV4Client drops the cached v4 client of a profile
+func deleteV4Client(n *NutanixClient) {
+	v4ClientsMu.Lock()
+	defer v4ClientsMu.Unlock()
+
+	delete(v4Clients, n.Key())
+}
+
+// newV4Client creates the v4 API clients of a connection, authenticating with its API key or basic credentials.
+// The actuator API of prism-go-client is not created, use NutanixClient.VersionRoutes instead.
+func newV4Client(conn connection, transport *http.Transport) (*prismclientv4.Client, error) {
+	host := conn.Address.Hostname()
+	port := 9440
+	if p := conn.Address.Port(); p != "" {
+		var err error
+		if port, err = strconv.Atoi(p); err != nil {
+			return nil, fmt.Errorf("invalid port %q: %w", p, err)
+		}
+	}
+
+	vmm := vmClient.NewApiClient()
+	vmm.Host, vmm.Port = host, port
+	vmm.Username, vmm.Password = v4Credentials(conn)
+
+	networking := networkingClient.NewApiClient()
+	networking.Host, networking.Port = host, port
+	networking.Username, networking.Password = v4Credentials(conn)
+
+	cluster := clusterClient.NewApiClient()
+	cluster.Host, cluster.Port = host, port
+	cluster.Username, cluster.Password = v4Credentials(conn)
+
+	prism := prismClient.NewApiClient()
+	prism.Host, prism.Port = host, port
+	prism.Username, prism.Password = v4Credentials(conn)
+
+	storage := storageClient.NewApiClient()
+	storage.Host, storage.Port = host, port
+	storage.Username, storage.Password = v4Credentials(conn)
+
+	volumes := volumesClient.NewApiClient()
+	volumes.Host, volumes.Port = host, port
+	volumes.Username, volumes.Password = v4Credentials(conn)
+
+	// The transport skips the default verification and verifies in VerifyConnection instead, see newTransport.
+	// The SDK rebuilds a transport whose InsecureSkipVerify differs from !VerifySSL, so VerifySSL is always false.
+	vmm.VerifySSL, networking.VerifySSL, cluster.VerifySSL = false, false, false
+	prism.VerifySSL, storage.VerifySSL, volumes.VerifySSL = false, false, false
+
+	for _, apiClient := range []v4APIClient{vmm, networking, cluster, prism, storage, volumes} {
+		if key := apiKey(conn.ManagementEndpoint); key != "" {
+			apiClient.AddDefaultHeader(apiKeyHeader, key)
+		}
+		if err := setTransport(apiClient, transport); err != nil {
+			return nil, err
+		}
+	}
+
+	return &prismclientv4.Client{
+		CategoriesApiInstance:   prismApi.NewCategoriesApi(prism),
+		ClustersApiInstance:     clusterApi.NewClustersApi(cluster),
+		ImagesApiInstance:       vmApi.NewImagesApi(vmm),
+		StorageContainerAPI:     storageApi.NewStorageContainerApi(storage),
+		SubnetsApiInstance:      networkingApi.NewSubnetsApi(networking),
+		SubnetIPReservationApi:  networkingApi.NewSubnetIPReservationApi(networking),
+		TasksApiInstance:        prismApi.NewTasksApi(prism),
+		VolumeGroupsApiInstance: volumesApi.NewVolumeGroupsApi(volumes),
+		VmApiInstance:           vmApi.NewVmApi(vmm),
+	}, nil
+}
+
+// v4Credentials returns the basic credentials of the v4 API clients, which are none for API keys.
+// The SDK sends them until Prism Central returns a session cookie, and again when the session expires.
+func v4Credentials(conn connection) (string, string) {
+	if apiKey(conn.ManagementEndpoint) != "" {
+		return "", ""
+	}
+
+	return conn.Username, conn.Password
+}
+
+// retryClientType is the type of the unexported retryClient field of the v4 API clients, see setTransport
+var retryClientType = reflect.TypeOf((*retryablehttp.Client)(nil))
+
+// setTransport replaces the transport of a v4 API client. The SDK has no option for it: it builds its transport from
+// VerifySSL and keeps it in the HTTP client of an unexported retryablehttp client, which is reached through reflection.
+// A client without that field fails instead of silently using the transport of the SDK, see TestV4RetryClient.
+func setTransport(apiClient v4APIClient, transport *http.Transport) error {
+	field := reflect.ValueOf(apiClient).Elem().FieldByName("retryClient")
+	if !field.IsValid() || field.Type() != retryClientType || field.IsNil() {
+		return fmt.Errorf("unsupported v4 API client %T: no retryablehttp client", apiClient)
+	}
+
+	retryClient := (*retryablehttp.Client)(field.UnsafePointer())
+	if retryClient.HTTPClient == nil {
+		return fmt.Errorf("unsupported v4 API client %T: no HTTP client", apiClient)
+	}
+	retryClient.HTTPClient.Transport = transport
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	envtypes "github.com/nutanix-cloud-native/prism-go-client/environment/types"
+	clusterClient "github.com/nutanix/ntnx-api-golang-clients/clustermgmt-go-client/v4/client"
+	networkingClient "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/client"
+	prismClient "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/client"
+	storageClient "github.com/nutanix/ntnx-api-golang-clients/storage-go-client/v4/client"
+	vmClient "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/client"
+	volumesClient "github.com/nutanix/ntnx-api-golang-clients/volumes-go-client/v4/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestV4RetryClient fails when an upgrade of the v4 SDK of go.mod renames or retypes the field setTransport writes
+func TestV4RetryClient(t *testing.T) {
+	for _, apiClient := range []v4APIClient{
+		vmClient.NewApiClient(),
+		networkingClient.NewApiClient(),
+		clusterClient.NewApiClient(),
+		prismClient.NewApiClient(),
+		storageClient.NewApiClient(),
+		volumesClient.NewApiClient(),
+	} {
+		field, ok := reflect.TypeOf(apiClient).Elem().FieldByName("retryClient")
+		require.True(t, ok, "%T", apiClient)
+		assert.Equal(t, retryClientType, field.Type, "%T", apiClient)
+		assert.NoError(t, setTransport(apiClient, &http.Transport{}), "%T", apiClient)
+	}
+}
+
+func TestV4ClientKeepsTransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	// The SDK calls through the injected transport rather than rebuilding its own
+	var dials atomic.Int32
+	transport := &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dials.Add(1)
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}
+	c, err := newV4Client(connection{ManagementEndpoint: envtypes.ManagementEndpoint{
+		Address:        u,
+		ApiCredentials: envtypes.ApiCredentials{Username: "admin", Password: "secret"},
+	}}, transport)
+	require.NoError(t, err)
+
+	_, err = c.ClustersApiInstance.ListClusters(nil, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Positive(t, dials.Load())
+}
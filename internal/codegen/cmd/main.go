@@ -21,7 +21,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	fmt.Printf("Generating resource and tool files in: %s\n", absPath)
+	fmt.Printf("Generating resource, tool and registry files in: %s\n", absPath)
 
 	// Generate all resource files
 	if err := templates.GenerateResourceFiles(absPath); err != nil {
@@ -29,11 +29,17 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Generate all resource files
+	// Generate all tool files
 	if err := templates.GenerateToolFiles(absPath); err != nil {
 		fmt.Printf("Error generating files: %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Println("Resource and tool files generated successfully!")
+	// Generate the registry of resources and tools
+	if err := templates.GenerateRegistryFile(absPath); err != nil {
+		fmt.Printf("Error generating files: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("Resource, tool and registry files generated successfully!")
 }
@@ -0,0 +1,100 @@
+package templates
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+)
+
+// Template for the resource registry
+const registryTemplate = `package registry
+
+import (
+    "github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+    "github.com/thunderboltsid/mcp-nutanix/pkg/tools"
+)
+
+// Resources returns the registrations of all generated resources and their tools
+func Resources() []ResourceRegistration {
+    return []ResourceRegistration{
+        {{- range .}}
+        {
+            Name: string(resources.ResourceType{{.Name}}),
+            Tools: []ToolRegistration{
+                {{- if .HasListFunc}}
+                {
+                    Func:    tools.{{.Name}}List,
+                    Handler: tools.{{.Name}}ListHandler(),
+                },
+                {
+                    Func:    tools.{{.Name}}Count,
+                    Handler: tools.{{.Name}}CountHandler(),
+                },
+                {{- end}}
+            },
+            ResourceFunc:    resources.{{.Name}},
+            ResourceHandler: resources.{{.Name}}Handler(),
+        },
+        {{- end}}
+    }
+}
+`
+
+// Template for the getters of all resources, used by tools reading resources of any type
+const gettersTemplate = `package resources
+
+// Getters maps every resource type to the function getting a resource by its UUID or extId
+var Getters = map[ResourceType]ResourceHandlerFunc{
+    {{- range .}}
+    ResourceType{{.Name}}: Get{{.Name}},
+    {{- end}}
+}
+`
+
+// GenerateRegistryFile generates the registry of all Nutanix resources and their tools,
+// and the getters of all resources used by tools reading resources of any type
+func GenerateRegistryFile(baseDir string) error {
+	resources := GetResourceDefinitions()
+
+	// Create the registry directory if it doesn't exist
+	registryDir := fmt.Sprintf("%s/pkg/registry", baseDir)
+	err := os.MkdirAll(registryDir, 0755)
+	if err != nil {
+		return fmt.Errorf("error creating registry directory: %w", err)
+	}
+
+	// Parse the registry template
+	tmpl, err := template.New("registry").Parse(registryTemplate)
+	if err != nil {
+		return fmt.Errorf("error parsing registry template: %w", err)
+	}
+
+	// Create registry file
+	registryFile, err := os.Create(fmt.Sprintf("%s/resources.go", registryDir))
+	if err != nil {
+		return fmt.Errorf("error creating registry file: %w", err)
+	}
+	defer registryFile.Close()
+
+	// Execute the template
+	if err := tmpl.Execute(registryFile, resources); err != nil {
+		return fmt.Errorf("error executing registry template: %w", err)
+	}
+
+	// Generate the getters of all resources
+	tmplGetters, err := template.New("getters").Parse(gettersTemplate)
+	if err != nil {
+		return fmt.Errorf("error parsing getters template: %w", err)
+	}
+	gettersFile, err := os.Create(fmt.Sprintf("%s/pkg/resources/getters.go", baseDir))
+	if err != nil {
+		return fmt.Errorf("error creating getters file: %w", err)
+	}
+	defer gettersFile.Close()
+
+	if err := tmplGetters.Execute(gettersFile, resources); err != nil {
+		return fmt.Errorf("error executing getters template: %w", err)
+	}
+
+	return nil
+}
@@ -3,7 +3,6 @@ package templates
 import (
 	"fmt"
 	"os"
-	"strings"
 	"text/template"
 )
 
@@ -17,6 +16,46 @@ type Resource struct {
 	ClientListAllFunc string // ListAll function with filter string parameter
 	HasListFunc       bool   // Whether the service has a ListX function
 	HasListAllFunc    bool   // Whether the service has a ListAllX function
+	ListMetadataType  string // Metadata type passed to the List function (defaults to DSMetadata)
+	FilterExample     string // Example FIQL filter shown in the tool description
+	HasSummaryView    bool   // Whether the resource supports view=summary, see resources.HasSummaryView
+
+	// v4 API definitions
+	APIVersion    string   // Prism API version targeted by the definition, "v3" (default) or "v4"
+	V4APIInstance string   // Field of the v4 client holding the API, e.g. VmApiInstance
+	V4ListParams  []string // OData parameters accepted by the List function after $page and $limit
+	GetExtraArgs  string   // Extra trailing arguments passed to the Get function
+}
+
+// IsV4 returns whether the definition targets a v4 API
+func (r Resource) IsV4() bool {
+	return r.APIVersion == "v4"
+}
+
+// MetadataType returns the v3 metadata type used by the List function
+func (r Resource) MetadataType() string {
+	if r.ListMetadataType == "" {
+		return "DSMetadata"
+	}
+
+	return r.ListMetadataType
+}
+
+// FilterHint returns an example FIQL (v3) or OData (v4) filter for the resource
+func (r Resource) FilterHint() string {
+	if r.FilterExample == "" && r.IsV4() {
+		return "startswith(name, 'prod')"
+	}
+	if r.FilterExample == "" {
+		return "name==prod.*"
+	}
+
+	return r.FilterExample
+}
+
+// FileName returns the name of the generated Go file for the resource
+func (r Resource) FileName() string {
+	return r.ResourceType + ".go"
 }
 
 const resourceTemplate = `package resources
@@ -33,7 +72,7 @@ import (
 // {{.Name}} defines the {{.Name}} resource template
 func {{.Name}}() mcp.ResourceTemplate {
     return mcp.NewResourceTemplate(
-        string(ResourceURIPrefix(ResourceType{{.Name}})) + "{uuid}",
+        string(ResourceURIPrefix(ResourceType{{.Name}})) + "{uuid}{?fields,detail{{if .HasSummaryView}},view{{end}},jq,profile}",
         string(ResourceType{{.Name}}),
         mcp.WithTemplateDescription("{{.Description}}"),
         mcp.WithTemplateMIMEType("application/json"),
@@ -42,10 +81,54 @@ func {{.Name}}() mcp.ResourceTemplate {
 
 // {{.Name}}Handler implements the handler for the {{.Name}} resource
 func {{.Name}}Handler() server.ResourceTemplateHandlerFunc {
-    return CreateResourceHandler(ResourceType{{.Name}}, func(ctx context.Context, client *client.NutanixClient, uuid string) (interface{}, error) {
-        // Get the {{.Name}}
-        return client.V3().{{.ClientGetFunc}}(ctx, uuid)
-    })
+    return CreateResourceHandler(ResourceType{{.Name}}, Get{{.Name}})
+}
+
+// Get{{.Name}} gets a {{.Name}} by its UUID
+func Get{{.Name}}(ctx context.Context, client *client.NutanixClient, uuid string) (interface{}, error) {
+    v3Client, err := client.V3()
+    if err != nil {
+        return nil, err
+    }
+
+    return v3Client.{{.ClientGetFunc}}(ctx, uuid)
+}
+`
+
+const resourceTemplateV4 = `package resources
+
+import (
+    "context"
+
+    "github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+    "github.com/mark3labs/mcp-go/mcp"
+    "github.com/mark3labs/mcp-go/server"
+)
+
+// {{.Name}} defines the {{.Name}} resource template
+func {{.Name}}() mcp.ResourceTemplate {
+    return mcp.NewResourceTemplate(
+        string(ResourceURIPrefix(ResourceType{{.Name}})) + "{extId}{?fields,detail,jq,profile}",
+        string(ResourceType{{.Name}}),
+        mcp.WithTemplateDescription("{{.Description}}"),
+        mcp.WithTemplateMIMEType("application/json"),
+    )
+}
+
+// {{.Name}}Handler implements the handler for the {{.Name}} resource
+func {{.Name}}Handler() server.ResourceTemplateHandlerFunc {
+    return CreateResourceHandler(ResourceType{{.Name}}, Get{{.Name}})
+}
+
+// Get{{.Name}} gets a {{.Name}} by its extId
+func Get{{.Name}}(ctx context.Context, client *client.NutanixClient, extID string) (interface{}, error) {
+    v4Client, err := client.V4()
+    if err != nil {
+        return nil, err
+    }
+
+    return v4Client.{{.V4APIInstance}}.{{.ClientGetFunc}}(&extID{{.GetExtraArgs}})
 }
 `
 
@@ -61,6 +144,200 @@ func GetResourceDefinitions() []Resource {
 			ClientListAllFunc: "ListAllVM",
 			HasListFunc:       true,
 			HasListAllFunc:    true,
+			FilterExample:     "vm_name==web.*;power_state==on",
+			HasSummaryView:    true,
+		},
+		{
+			Name:              "Cluster",
+			ResourceType:      "cluster",
+			Description:       "Cluster resource",
+			ClientGetFunc:     "GetCluster",
+			ClientListFunc:    "ListCluster",
+			ClientListAllFunc: "ListAllCluster",
+			HasListFunc:       true,
+			HasListAllFunc:    true,
+		},
+		{
+			Name:           "Host",
+			ResourceType:   "host",
+			Description:    "Host resource",
+			ClientGetFunc:  "GetHost",
+			ClientListFunc: "ListHost",
+			HasListFunc:    true,
+		},
+		{
+			Name:              "Image",
+			ResourceType:      "image",
+			Description:       "Image resource",
+			ClientGetFunc:     "GetImage",
+			ClientListFunc:    "ListImage",
+			ClientListAllFunc: "ListAllImage",
+			HasListFunc:       true,
+			HasListAllFunc:    true,
+		},
+		{
+			Name:              "Subnet",
+			ResourceType:      "subnet",
+			Description:       "Subnet resource",
+			ClientGetFunc:     "GetSubnet",
+			ClientListFunc:    "ListSubnet",
+			ClientListAllFunc: "ListAllSubnet",
+			HasListFunc:       true,
+			HasListAllFunc:    true,
+		},
+		{
+			Name:              "Project",
+			ResourceType:      "project",
+			Description:       "Project resource",
+			ClientGetFunc:     "GetProject",
+			ClientListFunc:    "ListProject",
+			ClientListAllFunc: "ListAllProject",
+			HasListFunc:       true,
+			HasListAllFunc:    true,
+		},
+		{
+			Name:             "Category",
+			ResourceType:     "category",
+			Description:      "Category key resource, addressed by category name",
+			ClientGetFunc:    "GetCategoryKey",
+			ClientListFunc:   "ListCategories",
+			HasListFunc:      true,
+			ListMetadataType: "CategoryListMetadata",
+		},
+		{
+			Name:              "NetworkSecurityRule",
+			ResourceType:      "network_security_rule",
+			Description:       "Network Security Rule resource",
+			ClientGetFunc:     "GetNetworkSecurityRule",
+			ClientListFunc:    "ListNetworkSecurityRule",
+			ClientListAllFunc: "ListAllNetworkSecurityRule",
+			HasListFunc:       true,
+			HasListAllFunc:    true,
+		},
+		{
+			Name:           "VolumeGroup",
+			ResourceType:   "volume_group",
+			Description:    "Volume Group resource",
+			ClientGetFunc:  "GetVolumeGroup",
+			ClientListFunc: "ListVolumeGroup",
+			HasListFunc:    true,
+		},
+		{
+			Name:              "ProtectionRule",
+			ResourceType:      "protection_rule",
+			Description:       "Protection Rule resource",
+			ClientGetFunc:     "GetProtectionRule",
+			ClientListFunc:    "ListProtectionRules",
+			ClientListAllFunc: "ListAllProtectionRules",
+			HasListFunc:       true,
+			HasListAllFunc:    true,
+		},
+		{
+			Name:              "RecoveryPlan",
+			ResourceType:      "recovery_plan",
+			Description:       "Recovery Plan resource",
+			ClientGetFunc:     "GetRecoveryPlan",
+			ClientListFunc:    "ListRecoveryPlans",
+			ClientListAllFunc: "ListAllRecoveryPlans",
+			HasListFunc:       true,
+			HasListAllFunc:    true,
+		},
+		{
+			Name:              "User",
+			ResourceType:      "user",
+			Description:       "User resource",
+			ClientGetFunc:     "GetUser",
+			ClientListFunc:    "ListUser",
+			ClientListAllFunc: "ListAllUser",
+			HasListFunc:       true,
+			HasListAllFunc:    true,
+		},
+		{
+			Name:              "Role",
+			ResourceType:      "role",
+			Description:       "Role resource",
+			ClientGetFunc:     "GetRole",
+			ClientListFunc:    "ListRole",
+			ClientListAllFunc: "ListAllRole",
+			HasListFunc:       true,
+			HasListAllFunc:    true,
+		},
+		{
+			Name:              "AccessControlPolicy",
+			ResourceType:      "access_control_policy",
+			Description:       "Access Control Policy resource",
+			ClientGetFunc:     "GetAccessControlPolicy",
+			ClientListFunc:    "ListAccessControlPolicy",
+			ClientListAllFunc: "ListAllAccessControlPolicy",
+			HasListFunc:       true,
+			HasListAllFunc:    true,
+		},
+		{
+			Name:           "VmmVM",
+			ResourceType:   "vmm_vm",
+			Description:    "Virtual Machine resource (v4 vmm API), addressed by extId",
+			APIVersion:     "v4",
+			V4APIInstance:  "VmApiInstance",
+			ClientGetFunc:  "GetVmById",
+			ClientListFunc: "ListVms",
+			HasListFunc:    true,
+			V4ListParams:   []string{"filter", "orderby", "select"},
+			FilterExample:  "startswith(name, 'web')",
+		},
+		{
+			Name:           "VmmImage",
+			ResourceType:   "vmm_image",
+			Description:    "Image resource (v4 vmm API), addressed by extId",
+			APIVersion:     "v4",
+			V4APIInstance:  "ImagesApiInstance",
+			ClientGetFunc:  "GetImageById",
+			ClientListFunc: "ListImages",
+			HasListFunc:    true,
+			V4ListParams:   []string{"filter", "orderby", "select"},
+		},
+		{
+			Name:           "ClustermgmtCluster",
+			ResourceType:   "clustermgmt_cluster",
+			Description:    "Cluster resource (v4 clustermgmt API), addressed by extId",
+			APIVersion:     "v4",
+			V4APIInstance:  "ClustersApiInstance",
+			ClientGetFunc:  "GetClusterById",
+			ClientListFunc: "ListClusters",
+			HasListFunc:    true,
+			V4ListParams:   []string{"filter", "orderby", "apply", "select"},
+		},
+		{
+			Name:           "NetworkingSubnet",
+			ResourceType:   "networking_subnet",
+			Description:    "Subnet resource (v4 networking API), addressed by extId",
+			APIVersion:     "v4",
+			V4APIInstance:  "SubnetsApiInstance",
+			ClientGetFunc:  "GetSubnetById",
+			ClientListFunc: "ListSubnets",
+			HasListFunc:    true,
+			V4ListParams:   []string{"filter", "orderby", "expand", "select"},
+		},
+		{
+			Name:           "StorageContainer",
+			ResourceType:   "storage_container",
+			Description:    "Storage Container resource (v4 storage API), addressed by extId",
+			APIVersion:     "v4",
+			V4APIInstance:  "StorageContainerAPI",
+			ClientGetFunc:  "GetStorageContainerByExtId",
+			ClientListFunc: "GetAllStorageContainers",
+			HasListFunc:    true,
+			V4ListParams:   []string{"filter", "orderby", "select"},
+		},
+		{
+			Name:           "VolumesVolumeGroup",
+			ResourceType:   "volumes_volume_group",
+			Description:    "Volume Group resource (v4 volumes API), addressed by extId",
+			APIVersion:     "v4",
+			V4APIInstance:  "VolumeGroupsApiInstance",
+			ClientGetFunc:  "GetVolumeGroupById",
+			ClientListFunc: "ListVolumeGroups",
+			HasListFunc:    true,
+			V4ListParams:   []string{"filter", "orderby", "expand", "select"},
 		},
 	}
 }
@@ -76,16 +353,20 @@ func GenerateResourceFiles(baseDir string) error {
 		return fmt.Errorf("error creating resources directory: %w", err)
 	}
 
-	// Parse the resource template
+	// Parse the resource templates
 	tmpl, err := template.New("resource").Parse(resourceTemplate)
 	if err != nil {
 		return fmt.Errorf("error parsing resource template: %w", err)
 	}
+	tmplV4, err := template.New("resourceV4").Parse(resourceTemplateV4)
+	if err != nil {
+		return fmt.Errorf("error parsing v4 resource template: %w", err)
+	}
 
 	// Generate resource files
 	for _, res := range resources {
 		// Create resource file
-		resourceFilePath := fmt.Sprintf("%s/%s.go", resourcesDir, strings.ToLower(res.Name))
+		resourceFilePath := fmt.Sprintf("%s/%s", resourcesDir, res.FileName())
 		resourceFile, err := os.Create(resourceFilePath)
 		if err != nil {
 			fmt.Printf("Error creating resource file for %s: %v\n", res.Name, err)
@@ -93,8 +374,12 @@ func GenerateResourceFiles(baseDir string) error {
 		}
 		defer resourceFile.Close()
 
-		// Execute the template
-		err = tmpl.Execute(resourceFile, res)
+		// Execute the template matching the API version
+		if res.IsV4() {
+			err = tmplV4.Execute(resourceFile, res)
+		} else {
+			err = tmpl.Execute(resourceFile, res)
+		}
 		if err != nil {
 			fmt.Printf("Error executing resource template for %s: %v\n", res.Name, err)
 		}
@@ -3,7 +3,6 @@ package templates
 import (
 	"fmt"
 	"os"
-	"strings"
 	"text/template"
 )
 
@@ -19,17 +18,29 @@ import (
 
     "github.com/mark3labs/mcp-go/mcp"
     "github.com/mark3labs/mcp-go/server"
-    "github.com/nutanix-cloud-native/prism-go-client/v3"
 )
 
-// {{.Name}} defines the {{.Name}} tool
+// {{.Name}}List defines the {{.Name}} list tool
 func {{.Name}}List() mcp.Tool {
-    return mcp.NewTool("{{.ResourceType}}_list",
-        mcp.WithDescription("List {{.ResourceType}} resources"),
+    opts := []mcp.ToolOption{
+        mcp.WithDescription("List {{.ResourceType}} resources one page at a time"),
         mcp.WithString("filter",
-           mcp.Description("Optional text filter (interpreted by LLM)"),
+           mcp.Description("Optional Prism FIQL filter, e.g. {{.FilterHint}} (';' is AND, ',' is OR)"),
         ),
-    )
+        mcp.WithString("fields",
+           mcp.Description("Optional comma-separated entity field paths to return, e.g. spec.name,metadata.uuid"),
+        ),
+        withDetailArgument(),
+        {{- if .HasSummaryView}}
+        withViewArgument(),
+        {{- end}}
+        withJQArgument(),
+        withFormatArgument(),
+        withMaxBytesArgument(),
+        withProfileArgument(),
+    }
+
+    return mcp.NewTool("{{.ResourceType}}_list", append(opts, withPagingArguments()...)...)
 }
 
 // {{.Name}}ListHandler implements the handler for the {{.Name}} list tool
@@ -37,16 +48,14 @@ func {{.Name}}ListHandler() server.ToolHandlerFunc {
     return CreateListToolHandler(
         resources.ResourceType{{.Name}},
         // Define the ListResourceFunc implementation
-        func(ctx context.Context, client *client.NutanixClient, filter string) (interface{}, error) {
-            {{if .HasListAllFunc}}
-            // Use ListAll function to get all resources
-            return client.V3().{{.ClientListAllFunc}}(ctx, "")
-            {{else}}
-            // Create DSMetadata without filter
-            metadata := &v3.DSMetadata{}
-            
-            return client.V3().{{.ClientListFunc}}(ctx, metadata)
-            {{end}}
+        func(ctx context.Context, client *client.NutanixClient, opts ListOptions) (interface{}, error) {
+            v3Client, err := client.V3()
+            if err != nil {
+                return nil, err
+            }
+
+            // Fetch a single page using the paging and sorting options
+            return v3Client.{{.ClientListFunc}}(ctx, opts.{{.MetadataType}}("{{.ResourceType}}"))
         },
     )
 }
@@ -56,8 +65,9 @@ func {{.Name}}Count() mcp.Tool {
     return mcp.NewTool("{{.ResourceType}}_count",
         mcp.WithDescription("Count {{.ResourceType}} resources"),
         mcp.WithString("filter",
-           mcp.Description("Optional text filter (interpreted by LLM)"),
+           mcp.Description("Optional Prism FIQL filter, e.g. {{.FilterHint}} (';' is AND, ',' is OR)"),
         ),
+        withProfileArgument(),
     )
 }
 
@@ -66,32 +76,111 @@ func {{.Name}}CountHandler() server.ToolHandlerFunc {
     return CreateCountToolHandler(
         resources.ResourceType{{.Name}},
         // Define the ListResourceFunc implementation
-        func(ctx context.Context, client *client.NutanixClient, filter string) (interface{}, error) {
-            {{if .HasListAllFunc}}
-            // Use ListAll function to get all resources
-            resp, err := client.V3().{{.ClientListAllFunc}}(ctx, "")
-            {{else}}
-            // Create DSMetadata without filter
-            metadata := &v3.DSMetadata{}
-            
-            resp, err := client.V3().{{.ClientListFunc}}(ctx, metadata)
-            {{end}}
+        func(ctx context.Context, client *client.NutanixClient, opts ListOptions) (interface{}, error) {
+            v3Client, err := client.V3()
+            if err != nil {
+                return nil, err
+            }
+
+            // Fetch a single entity; the server-side total covers the rest
+            opts.Length = 1
+            resp, err := v3Client.{{.ClientListFunc}}(ctx, opts.{{.MetadataType}}("{{.ResourceType}}"))
             if err != nil {
-				return nil, err
-			}
+                return nil, err
+            }
+            count, ok := totalMatches(resp)
+            if !ok {
+                return nil, fmt.Errorf("response has no total_matches")
+            }
+
+            res := map[string]interface{}{
+                "resource_type": "{{.Name}}",
+                "count":         count,
+                "metadata":      resp.Metadata,
+            }
+
+            return res, nil
+        },
+    )
+}
+`
+
+// Templates for v4 tool implementations
+const toolTemplateV4 = `package tools
+
+import (
+    "context"
+
+    "github.com/thunderboltsid/mcp-nutanix/internal/client"
+    "github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+
+    "github.com/mark3labs/mcp-go/mcp"
+    "github.com/mark3labs/mcp-go/server"
+)
 
-			res := map[string]interface{}{
-				"resource_type": "{{.Name}}",
-				"count": len(resp.Entities),
-				"metadata": resp.Metadata,
-        	}
+// list{{.Name}} fetches a single page of {{.Name}} resources from the v4 API
+func list{{.Name}}(ctx context.Context, client *client.NutanixClient, opts V4ListOptions) (interface{}, error) {
+    v4Client, err := client.V4()
+    if err != nil {
+        return nil, err
+    }
 
-			return res, nil
-		},
+    return v4Client.{{.V4APIInstance}}.{{.ClientListFunc}}(opts.Page, opts.Limit{{range .V4ListParams}}, {{v4ListArg .}}{{end}})
+}
+
+// {{.Name}}List defines the {{.Name}} list tool
+func {{.Name}}List() mcp.Tool {
+    opts := []mcp.ToolOption{
+        mcp.WithDescription("List {{.ResourceType}} resources one page at a time using the v4 API"),
+        mcp.WithString("filter",
+           mcp.Description("Optional OData $filter expression, e.g. {{.FilterHint}}"),
+        ),
+        withDetailArgument(),
+        withJQArgument(),
+        withFormatArgument(),
+        withMaxBytesArgument(),
+        withProfileArgument(),
+    }
+
+    return mcp.NewTool("{{.ResourceType}}_list", append(opts, withODataArguments()...)...)
+}
+
+// {{.Name}}ListHandler implements the handler for the {{.Name}} list tool
+func {{.Name}}ListHandler() server.ToolHandlerFunc {
+    return CreateV4ListToolHandler(resources.ResourceType{{.Name}}, list{{.Name}})
+}
+
+// {{.Name}}Count defines the {{.Name}} count tool
+func {{.Name}}Count() mcp.Tool {
+    return mcp.NewTool("{{.ResourceType}}_count",
+        mcp.WithDescription("Count {{.ResourceType}} resources using the v4 API"),
+        mcp.WithString("filter",
+           mcp.Description("Optional OData $filter expression, e.g. {{.FilterHint}}"),
+        ),
+        withProfileArgument(),
     )
 }
+
+// {{.Name}}CountHandler implements the handler for the {{.Name}} count tool
+func {{.Name}}CountHandler() server.ToolHandlerFunc {
+    return CreateV4CountToolHandler(resources.ResourceType{{.Name}}, list{{.Name}})
+}
 `
 
+// v4ListArg maps an OData parameter of a v4 List function to the V4ListOptions field that fills it
+func v4ListArg(param string) string {
+	switch param {
+	case "filter":
+		return "opts.Filter"
+	case "orderby":
+		return "opts.OrderBy"
+	case "select":
+		return "opts.Select"
+	default:
+		return "nil"
+	}
+}
+
 // GenerateToolFiles generates tool files for all Nutanix resources that support listing
 func GenerateToolFiles(baseDir string) error {
 	resources := GetResourceDefinitions()
@@ -103,22 +192,26 @@ func GenerateToolFiles(baseDir string) error {
 		return fmt.Errorf("error creating tools directory: %w", err)
 	}
 
-	// Parse the tool template
+	// Parse the tool templates
 	toolTmpl, err := template.New("tool").Parse(toolTemplate)
 	if err != nil {
 		return fmt.Errorf("error parsing tool template: %w", err)
 	}
+	toolTmplV4, err := template.New("toolV4").Funcs(template.FuncMap{"v4ListArg": v4ListArg}).Parse(toolTemplateV4)
+	if err != nil {
+		return fmt.Errorf("error parsing v4 tool template: %w", err)
+	}
 
 	// Generate tool files
 	for _, res := range resources {
-		// Skip resources that don't support listing
-		if !res.HasListFunc && !res.HasListAllFunc {
+		// Skip resources that don't support paged listing
+		if !res.HasListFunc {
 			fmt.Printf("Skipping tool generation for %s: no list capability\n", res.Name)
 			continue
 		}
 
 		// Create tool file
-		toolFilePath := fmt.Sprintf("%s/%s.go", toolsDir, strings.ToLower(res.Name))
+		toolFilePath := fmt.Sprintf("%s/%s", toolsDir, res.FileName())
 		toolFile, err := os.Create(toolFilePath)
 		if err != nil {
 			fmt.Printf("Error creating tool file for %s: %v\n", res.Name, err)
@@ -126,8 +219,12 @@ func GenerateToolFiles(baseDir string) error {
 		}
 		defer toolFile.Close()
 
-		// Execute the template
-		err = toolTmpl.Execute(toolFile, res)
+		// Execute the template matching the API version
+		if res.IsV4() {
+			err = toolTmplV4.Execute(toolFile, res)
+		} else {
+			err = toolTmpl.Execute(toolFile, res)
+		}
 		if err != nil {
 			fmt.Printf("Error executing tool template for %s: %v\n", res.Name, err)
 		}
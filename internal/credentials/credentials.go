@@ -0,0 +1,175 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// EnvNetrc is the path of the netrc file read by DefaultChain
+	EnvNetrc = "MCP_NETRC"
+	// EnvHelper is the helper command run by DefaultChain
+	EnvHelper = "MCP_CREDENTIAL_HELPER"
+
+	helperTimeout = 30 * time.Second
+)
+
+// ErrNotFound is returned when no source of a chain knows the credentials
+var ErrNotFound = errors.New("credentials not found")
+
+// Request identifies the credentials to look up
+type Request struct {
+	// Service is the kind of credentials, e.g. nutanix or ssh
+	Service string
+	// Prefix is the prefix of the environment variables, e.g. NUTANIX for NUTANIX_PASSWORD and NUTANIX_PASSWORD_FILE
+	Prefix string
+	// Host is the host the credentials are for, matched against the machines of netrc files
+	Host string
+	// APIKey enables the lookup of an API key, which replaces the username and password
+	APIKey bool
+}
+
+// Credentials are a username and password, or an API key
+type Credentials struct {
+	Username string
+	Password string
+	APIKey   string
+}
+
+// complete reports whether both the username and the password, or the API key are known
+func (c Credentials) complete() bool {
+	return c.APIKey != "" || c.Username != "" && c.Password != ""
+}
+
+// merge fills the missing fields from other credentials
+func (c Credentials) merge(other Credentials) Credentials {
+	if c.Username == "" {
+		c.Username = other.Username
+	}
+	if c.Password == "" {
+		c.Password = other.Password
+	}
+	if c.APIKey == "" {
+		c.APIKey = other.APIKey
+	}
+	return c
+}
+
+// Source is a source of credentials. Sources return the fields they know, which may be none;
+// known is what earlier sources of the chain found, e.g. the username a netrc entry has to match.
+type Source interface {
+	Name() string
+	Lookup(ctx context.Context, request Request, known Credentials) (Credentials, error)
+}
+
+// Chain looks up credentials from its sources in order, until both the username and the password or the API key are known.
+// An API key is returned without the username and password found along with it.
+type Chain []Source
+
+// DefaultChain returns the chain of environment variables, *_FILE environment variables,
+// the netrc file of MCP_NETRC and the helper command of MCP_CREDENTIAL_HELPER
+func DefaultChain() Chain {
+	chain := Chain{EnvSource{}, FileSource{}}
+	if path := os.Getenv(EnvNetrc); path != "" {
+		chain = append(chain, NetrcSource{Path: path})
+	}
+	if command := os.Getenv(EnvHelper); command != "" {
+		chain = append(chain, HelperSource{Command: command, Timeout: helperTimeout})
+	}
+
+	return chain
+}
+
+// Lookup returns the credentials found by the sources of the chain, or ErrNotFound if no source knows the password or API key
+func (c Chain) Lookup(ctx context.Context, request Request) (Credentials, error) {
+	var found Credentials
+	for _, source := range c {
+		if found.complete() {
+			break
+		}
+
+		creds, err := source.Lookup(ctx, request, found)
+		if err != nil {
+			return Credentials{}, fmt.Errorf("%s credentials from %s: %w", request.Service, source.Name(), err)
+		}
+		if !request.APIKey {
+			creds.APIKey = ""
+		}
+		found = found.merge(creds)
+	}
+
+	if found.APIKey != "" {
+		return Credentials{APIKey: found.APIKey}, nil
+	}
+	if found.Password == "" {
+		options := []string{request.Prefix + "_PASSWORD", request.Prefix + "_PASSWORD_FILE", EnvNetrc, EnvHelper}
+		if request.APIKey {
+			options = append([]string{request.Prefix + "_API_KEY", request.Prefix + "_API_KEY_FILE"}, options...)
+		}
+		return found, fmt.Errorf("%w for %s: set one of %s", ErrNotFound, request.Service, strings.Join(options, ", "))
+	}
+
+	return found, nil
+}
+
+// EnvSource reads the <prefix>_USERNAME, <prefix>_PASSWORD and <prefix>_API_KEY environment variables
+type EnvSource struct{}
+
+// Name implements the Source interface
+func (EnvSource) Name() string {
+	return "environment"
+}
+
+// Lookup implements the Source interface
+func (EnvSource) Lookup(_ context.Context, request Request, _ Credentials) (Credentials, error) {
+	return Credentials{
+		Username: strings.TrimSpace(os.Getenv(request.Prefix + "_USERNAME")),
+		Password: strings.TrimSpace(os.Getenv(request.Prefix + "_PASSWORD")),
+		APIKey:   strings.TrimSpace(os.Getenv(request.Prefix + "_API_KEY")),
+	}, nil
+}
+
+// FileSource reads the files named by the <prefix>_USERNAME_FILE, <prefix>_PASSWORD_FILE and <prefix>_API_KEY_FILE environment variables,
+// e.g. Docker or Kubernetes secrets
+type FileSource struct{}
+
+// Name implements the Source interface
+func (FileSource) Name() string {
+	return "files"
+}
+
+// Lookup implements the Source interface
+func (FileSource) Lookup(_ context.Context, request Request, _ Credentials) (Credentials, error) {
+	username, err := readSecretFile(os.Getenv(request.Prefix + "_USERNAME_FILE"))
+	if err != nil {
+		return Credentials{}, err
+	}
+	password, err := readSecretFile(os.Getenv(request.Prefix + "_PASSWORD_FILE"))
+	if err != nil {
+		return Credentials{}, err
+	}
+	apiKey, err := readSecretFile(os.Getenv(request.Prefix + "_API_KEY_FILE"))
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	return Credentials{Username: username, Password: password, APIKey: apiKey}, nil
+}
+
+// readSecretFile returns the content of a secret file without the trailing newline, or nothing for an empty path
+func readSecretFile(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
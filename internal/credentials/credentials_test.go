@@ -0,0 +1,139 @@
+package credentials
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestChain(t *testing.T) {
+	ctx := context.Background()
+	request := Request{Service: "nutanix", Prefix: "TEST", Host: "pc.example.com"}
+
+	// The username of the environment and the password of the file are combined
+	t.Setenv("TEST_USERNAME", "admin")
+	t.Setenv("TEST_PASSWORD_FILE", writeFile(t, "password", "from-file\n"))
+	creds, err := Chain{EnvSource{}, FileSource{}}.Lookup(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{Username: "admin", Password: "from-file"}, creds)
+
+	// Earlier sources win
+	t.Setenv("TEST_PASSWORD", "from-env")
+	creds, err = Chain{EnvSource{}, FileSource{}}.Lookup(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", creds.Password)
+
+	// Missing files are errors rather than skipped
+	t.Setenv("TEST_PASSWORD", "")
+	t.Setenv("TEST_PASSWORD_FILE", filepath.Join(t.TempDir(), "missing"))
+	_, err = Chain{EnvSource{}, FileSource{}}.Lookup(ctx, request)
+	assert.ErrorContains(t, err, "nutanix credentials from files")
+
+	t.Setenv("TEST_PASSWORD_FILE", "")
+	_, err = Chain{EnvSource{}, FileSource{}}.Lookup(ctx, request)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorContains(t, err, "TEST_PASSWORD_FILE")
+}
+
+func TestChainAPIKey(t *testing.T) {
+	ctx := context.Background()
+	request := Request{Service: "nutanix", Prefix: "TEST", Host: "pc.example.com", APIKey: true}
+
+	// An API key is returned alone, even with a username and password
+	t.Setenv("TEST_USERNAME", "admin")
+	t.Setenv("TEST_PASSWORD", "secret")
+	t.Setenv("TEST_API_KEY", "key")
+	creds, err := Chain{EnvSource{}, FileSource{}}.Lookup(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{APIKey: "key"}, creds)
+
+	// Later sources are only asked for what is missing
+	t.Setenv("TEST_API_KEY", "")
+	t.Setenv("TEST_API_KEY_FILE", writeFile(t, "api-key", "from-file\n"))
+	creds, err = Chain{EnvSource{}, FileSource{}}.Lookup(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{Username: "admin", Password: "secret"}, creds)
+
+	t.Setenv("TEST_PASSWORD", "")
+	creds, err = Chain{EnvSource{}, FileSource{}}.Lookup(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{APIKey: "from-file"}, creds)
+
+	// Requests without API keys ignore them
+	request.APIKey = false
+	_, err = Chain{EnvSource{}, FileSource{}}.Lookup(ctx, request)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NotContains(t, err.Error(), "TEST_API_KEY")
+
+	request.APIKey = true
+	t.Setenv("TEST_API_KEY_FILE", "")
+	_, err = Chain{EnvSource{}, FileSource{}}.Lookup(ctx, request)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorContains(t, err, "TEST_API_KEY_FILE")
+}
+
+func TestNetrcSource(t *testing.T) {
+	ctx := context.Background()
+	source := NetrcSource{Path: writeFile(t, "netrc", `
+# Prism Central
+default login nutanix password fallback
+machine pc.example.com login admin password secret
+machine pc.example.com login viewer password readonly
+macdef init
+machine ignored login ignored password ignored
+
+machine 10.0.0.10 login lab password
+  labsecret
+`)}
+
+	creds, err := source.Lookup(ctx, Request{Host: "https://pc.example.com:9440"}, Credentials{})
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{Username: "admin", Password: "secret"}, creds)
+
+	// A known username selects the entry with that login
+	creds, err = source.Lookup(ctx, Request{Host: "pc.example.com"}, Credentials{Username: "viewer"})
+	require.NoError(t, err)
+	assert.Equal(t, "readonly", creds.Password)
+
+	creds, err = source.Lookup(ctx, Request{Host: "10.0.0.10"}, Credentials{})
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{Username: "lab", Password: "labsecret"}, creds)
+
+	// Other hosts use the default entry, macro definitions are skipped
+	creds, err = source.Lookup(ctx, Request{Host: "ignored"}, Credentials{})
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{Username: "nutanix", Password: "fallback"}, creds)
+}
+
+func TestHelperSource(t *testing.T) {
+	ctx := context.Background()
+	request := Request{Service: "ssh", Host: "10.0.0.1:22"}
+
+	// The helper reads the request from stdin and prints key=value lines
+	source := HelperSource{Command: `while IFS== read -r key value; do [ "$key" = host ] && host=$value; done; echo "username=nutanix"; echo "password=for-$host"`}
+	creds, err := source.Lookup(ctx, request, Credentials{})
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{Username: "nutanix", Password: "for-10.0.0.1"}, creds)
+
+	// or just the password
+	creds, err = HelperSource{Command: "echo 'p=ss word'"}.Lookup(ctx, request, Credentials{})
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{Password: "p=ss word"}, creds)
+
+	creds, err = HelperSource{Command: "echo api_key=key"}.Lookup(ctx, request, Credentials{})
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{APIKey: "key"}, creds)
+
+	_, err = HelperSource{Command: "echo locked >&2; exit 1"}.Lookup(ctx, request, Credentials{})
+	assert.ErrorContains(t, err, "locked")
+}
@@ -0,0 +1,86 @@
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// HelperSource runs a helper command printing the credentials, like git credential helpers.
+// The command is run by sh and reads the request from stdin as key=value lines:
+//
+//	service=nutanix
+//	host=pc.example.com
+//	username=admin
+//
+// It prints username=... and password=... or api_key=... lines, or just the password.
+type HelperSource struct {
+	Command string
+	Timeout time.Duration
+}
+
+// Name implements the Source interface
+func (s HelperSource) Name() string {
+	return "credential helper"
+}
+
+// Lookup implements the Source interface
+func (s HelperSource) Lookup(ctx context.Context, request Request, known Credentials) (Credentials, error) {
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+
+	var input strings.Builder
+	fmt.Fprintf(&input, "service=%s\n", request.Service)
+	if request.Host != "" {
+		fmt.Fprintf(&input, "host=%s\n", hostname(request.Host))
+	}
+	if known.Username != "" {
+		fmt.Fprintf(&input, "username=%s\n", known.Username)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", s.Command)
+	cmd.Stdin = strings.NewReader(input.String())
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		// The output may hold secrets, so only stderr ends up in the error
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return Credentials{}, fmt.Errorf("%w: %s", err, message)
+		}
+		return Credentials{}, err
+	}
+
+	return parseHelperOutput(stdout.String()), nil
+}
+
+// parseHelperOutput parses the username=..., password=... and api_key=... lines printed by a helper,
+// or takes the first line as the password if there are none
+func parseHelperOutput(output string) Credentials {
+	var (
+		creds    Credentials
+		keyValue bool
+	)
+	lines := strings.Split(strings.TrimRight(output, "\r\n"), "\n")
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		if value, ok := strings.CutPrefix(line, "username="); ok {
+			creds.Username, keyValue = value, true
+		} else if value, ok := strings.CutPrefix(line, "password="); ok {
+			creds.Password, keyValue = value, true
+		} else if value, ok := strings.CutPrefix(line, "api_key="); ok {
+			creds.APIKey, keyValue = value, true
+		}
+	}
+	if !keyValue {
+		creds.Password = strings.TrimRight(lines[0], "\r")
+	}
+
+	return creds
+}
@@ -0,0 +1,160 @@
+package credentials
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// NetrcSource reads the login and password of the host from a netrc file:
+//
+//	machine pc.example.com login admin password secret
+//	default login nutanix password secret
+//
+// When a username is already known, only entries with that login match.
+type NetrcSource struct {
+	Path string
+}
+
+// netrcEntry is a machine or default entry of a netrc file
+type netrcEntry struct {
+	machine  string
+	login    string
+	password string
+}
+
+// Name implements the Source interface
+func (s NetrcSource) Name() string {
+	return "netrc file " + s.Path
+}
+
+// Lookup implements the Source interface
+func (s NetrcSource) Lookup(_ context.Context, request Request, known Credentials) (Credentials, error) {
+	entries, err := readNetrc(s.Path)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	host := hostname(request.Host)
+	for _, entry := range entries {
+		// The default entry, if any, comes last and matches every host
+		if entry.machine != "" && !strings.EqualFold(entry.machine, host) {
+			continue
+		}
+		if known.Username != "" && entry.login != "" && entry.login != known.Username {
+			continue
+		}
+		return Credentials{Username: entry.login, Password: entry.password}, nil
+	}
+
+	return Credentials{}, nil
+}
+
+// readNetrc parses the entries of a netrc file
+func readNetrc(path string) ([]netrcEntry, error) {
+	tokens, err := netrcTokens(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []netrcEntry
+	for i := 0; i < len(tokens); i++ {
+		switch token := tokens[i]; token {
+		case "machine", "default":
+			entry := netrcEntry{}
+			if token == "machine" {
+				if i+1 >= len(tokens) {
+					return nil, fmt.Errorf("netrc file %s: missing value of machine", path)
+				}
+				i++
+				entry.machine = tokens[i]
+			}
+			entries = append(entries, entry)
+		case "login", "password", "account":
+			if i+1 >= len(tokens) {
+				return nil, fmt.Errorf("netrc file %s: missing value of %s", path, token)
+			}
+			if len(entries) == 0 {
+				return nil, fmt.Errorf("netrc file %s: %s outside of a machine entry", path, token)
+			}
+			i++
+			entry := &entries[len(entries)-1]
+			switch token {
+			case "login":
+				entry.login = tokens[i]
+			case "password":
+				entry.password = tokens[i]
+			}
+		}
+	}
+
+	// Move the default entry behind the machine entries
+	for i, entry := range entries {
+		if entry.machine == "" {
+			entries = append(append(entries[:i:i], entries[i+1:]...), entry)
+			break
+		}
+	}
+
+	return entries, nil
+}
+
+// netrcTokens returns the tokens of a netrc file, which may span lines, without comments and macro definitions
+func netrcTokens(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open netrc file: %w", err)
+	}
+	defer file.Close()
+
+	var (
+		tokens  []string
+		inMacro bool
+	)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// A macro definition ends with an empty line
+		if inMacro {
+			inMacro = strings.TrimSpace(line) != ""
+			continue
+		}
+
+		for _, token := range strings.Fields(line) {
+			if strings.HasPrefix(token, "#") {
+				break
+			}
+			if token == "macdef" {
+				inMacro = true
+				break
+			}
+			tokens = append(tokens, token)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read netrc file: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// hostname strips the scheme and port from a host, e.g. https://pc.example.com:9440 to pc.example.com
+func hostname(host string) string {
+	if _, rest, found := strings.Cut(host, "://"); found {
+		host = rest
+	}
+	host, _, _ = strings.Cut(host, "/")
+	if strings.HasPrefix(host, "[") {
+		if end := strings.Index(host, "]"); end > 0 {
+			return host[1:end]
+		}
+	}
+	if strings.Count(host, ":") == 1 {
+		host, _, _ = strings.Cut(host, ":")
+	}
+
+	return host
+}
@@ -0,0 +1,189 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// DiffOp tells how a value differs between two documents
+type DiffOp string
+
+const (
+	// DiffAdded values are only in the right document
+	DiffAdded DiffOp = "added"
+	// DiffRemoved values are only in the left document
+	DiffRemoved DiffOp = "removed"
+	// DiffChanged values are in both documents with different values
+	DiffChanged DiffOp = "changed"
+)
+
+// DiffChange is a value differing between two documents
+type DiffChange struct {
+	Path  string      `json:"path"`
+	Op    DiffOp      `json:"op"`
+	Left  interface{} `json:"left,omitempty"`
+	Right interface{} `json:"right,omitempty"`
+}
+
+// VolatilePaths are ignored when diffing entities: the status and the metadata changing with every update,
+// such as spec_version and timestamps, as well as the default strip paths.
+// v4 Get responses hold the entity in data, next to a response metadata with flags and messages; only the extId of
+// the entity itself is ignored, the extIds nested in it reference other entities.
+var VolatilePaths = append(append([]string{}, DefaultStripPaths...),
+	"status",
+	"metadata.uuid",
+	"metadata.flags",
+	"metadata.messages",
+	"metadata.extraInfo",
+	"metadata.totalAvailableResults",
+	"extId",
+	"data.extId",
+	"..tenantId",
+	"..spec_version",
+	"..spec_hash",
+	"..entity_version",
+	"..creation_time",
+	"..last_update_time",
+	"..createTime",
+	"..lastUpdateTime",
+	"..links",
+)
+
+// diffSkippedKeys are never compared, e.g. the ETag held by $reserved of v4 entities
+var diffSkippedKeys = map[string]bool{
+	"$reserved": true,
+}
+
+// ParseDocument decodes a JSON document into maps, slices and json.Number values
+func ParseDocument(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("invalid JSON document: %w", err)
+	}
+
+	return value, nil
+}
+
+// Diff compares two documents, such as two entities or an entity and a snapshot, after deleting the
+// ignored paths and redacting secrets. Changes are ordered by path; arrays are compared by index.
+func Diff(left interface{}, right interface{}, ignore []string) ([]DiffChange, RedactionCounts, error) {
+	filter, err := cachedPathFilter(FilterDelete, ignore)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	redactions := RedactionCounts{}
+	values := []interface{}{left, right}
+	for i, value := range values {
+		decoded, err := decodeValue(value)
+		if err != nil {
+			return nil, nil, err
+		}
+		if decoded, err = filter.Apply(decoded); err != nil {
+			return nil, nil, err
+		}
+
+		var counts RedactionCounts
+		values[i], counts = Redact(decoded)
+		redactions.Add(counts)
+	}
+
+	changes := []DiffChange{}
+	diffValues("", values[0], values[1], &changes)
+
+	return changes, redactions, nil
+}
+
+func diffValues(path string, left interface{}, right interface{}, changes *[]DiffChange) {
+	switch l := left.(type) {
+	case map[string]interface{}:
+		r, ok := right.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		keys := make([]string, 0, len(l)+len(r))
+		for key := range l {
+			keys = append(keys, key)
+		}
+		for key := range r {
+			if _, ok := l[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if diffSkippedKeys[key] {
+				continue
+			}
+			diffMember(joinPath(path, key), l, r, key, changes)
+		}
+		return
+	case []interface{}:
+		r, ok := right.([]interface{})
+		if !ok {
+			break
+		}
+
+		for i := 0; i < len(l) || i < len(r); i++ {
+			itemPath := path + "[" + strconv.Itoa(i) + "]"
+			switch {
+			case i >= len(r):
+				*changes = append(*changes, DiffChange{Path: itemPath, Op: DiffRemoved, Left: l[i]})
+			case i >= len(l):
+				*changes = append(*changes, DiffChange{Path: itemPath, Op: DiffAdded, Right: r[i]})
+			default:
+				diffValues(itemPath, l[i], r[i], changes)
+			}
+		}
+		return
+	}
+
+	if !equalValues(left, right) {
+		*changes = append(*changes, DiffChange{Path: path, Op: DiffChanged, Left: left, Right: right})
+	}
+}
+
+func diffMember(path string, left map[string]interface{}, right map[string]interface{}, key string, changes *[]DiffChange) {
+	l, inLeft := left[key]
+	r, inRight := right[key]
+	switch {
+	case !inRight:
+		*changes = append(*changes, DiffChange{Path: path, Op: DiffRemoved, Left: l})
+	case !inLeft:
+		*changes = append(*changes, DiffChange{Path: path, Op: DiffAdded, Right: r})
+	default:
+		diffValues(path, l, r, changes)
+	}
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// equalValues compares decoded scalars, numbers by value so that 1 and 1.0 are equal
+func equalValues(left interface{}, right interface{}) bool {
+	l, lok := left.(json.Number)
+	r, rok := right.(json.Number)
+	if lok && rok {
+		if l == r {
+			return true
+		}
+		lf, lerr := l.Float64()
+		rf, rerr := r.Float64()
+		return lerr == nil && rerr == nil && lf == rf
+	}
+
+	return reflect.DeepEqual(left, right)
+}
@@ -0,0 +1,115 @@
+package json
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffEntities(t *testing.T) {
+	str := func(s string) *string { return &s }
+	num := func(i int64) *int64 { return &i }
+	vm := func(uuid string, version int64, memory int64, subnets ...string) *v3.VMIntentResponse {
+		created := time.Unix(version, 0)
+		nics := []*v3.VMNic{}
+		for _, subnet := range subnets {
+			nics = append(nics, &v3.VMNic{SubnetReference: &v3.Reference{Kind: str("subnet"), UUID: str(subnet)}})
+		}
+		return &v3.VMIntentResponse{
+			Metadata: &v3.Metadata{UUID: str(uuid), SpecVersion: num(version), CreationTime: &created},
+			Spec: &v3.VM{
+				Name: str("web"),
+				Resources: &v3.VMResources{
+					MemorySizeMib: num(memory),
+					NicList:       nics,
+					GuestCustomization: &v3.GuestCustomization{
+						CloudInit: &v3.GuestCustomizationCloudInit{UserData: str(uuid)},
+					},
+				},
+			},
+			Status: &v3.VMDefStatus{State: str("COMPLETE")},
+		}
+	}
+
+	left := vm("uuid-a", 1, 4096, "subnet-1")
+	right := vm("uuid-b", 7, 8192, "subnet-1", "subnet-2")
+	right.Spec.Resources.PowerState = str("ON")
+	right.Status.State = str("ERROR")
+
+	changes, _, err := Diff(left, right, VolatilePaths)
+	require.NoError(t, err)
+	assert.Equal(t, []DiffChange{
+		{Path: "spec.resources.memory_size_mib", Op: DiffChanged, Left: json.Number("4096"), Right: json.Number("8192")},
+		{Path: "spec.resources.nic_list[1]", Op: DiffAdded, Right: map[string]interface{}{
+			"subnet_reference": map[string]interface{}{"kind": "subnet", "uuid": "subnet-2"},
+		}},
+		{Path: "spec.resources.power_state", Op: DiffAdded, Right: "ON"},
+	}, changes)
+
+	// An entity does not differ from its own snapshot
+	data, err := RegularJSONEncoder(left).MarshalJSON()
+	require.NoError(t, err)
+	snapshot, err := ParseDocument(data)
+	require.NoError(t, err)
+	changes, _, err = Diff(left, snapshot, VolatilePaths)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	// Secrets are redacted before diffing
+	snapshot.(map[string]interface{})["spec"].(map[string]interface{})["resources"].(map[string]interface{})["admin_password"] = "hunter2"
+	changes, redactions, err := Diff(left, snapshot, VolatilePaths)
+	require.NoError(t, err)
+	assert.Equal(t, []DiffChange{{Path: "spec.resources.admin_password", Op: DiffAdded, Right: RedactedValue}}, changes)
+	assert.Equal(t, 1, redactions.Total())
+}
+
+func TestDiffV4Responses(t *testing.T) {
+	response := func(extID string, subnet string, flag bool) interface{} {
+		return map[string]interface{}{
+			"data": map[string]interface{}{
+				"extId":    extID,
+				"tenantId": "tenant-" + extID,
+				"name":     "web",
+				"nics": []interface{}{map[string]interface{}{
+					"extId":       "nic-" + extID,
+					"networkInfo": map[string]interface{}{"subnet": map[string]interface{}{"extId": subnet}},
+				}},
+				"$reserved": map[string]interface{}{"ETag": extID},
+			},
+			"metadata": map[string]interface{}{
+				"flags":    []interface{}{map[string]interface{}{"name": "hasError", "value": flag}},
+				"links":    []interface{}{map[string]interface{}{"href": "/api/vmm/v4.0/ahv/config/vms/" + extID}},
+				"messages": []interface{}{},
+			},
+		}
+	}
+
+	// The entity extId, the tenant and the response metadata are ignored, references are compared
+	changes, _, err := Diff(response("vm-a", "subnet-1", false), response("vm-b", "subnet-2", true), VolatilePaths)
+	require.NoError(t, err)
+	assert.Equal(t, []DiffChange{
+		{Path: "data.nics[0].extId", Op: DiffChanged, Left: "nic-vm-a", Right: "nic-vm-b"},
+		{Path: "data.nics[0].networkInfo.subnet.extId", Op: DiffChanged, Left: "subnet-1", Right: "subnet-2"},
+	}, changes)
+}
+
+func TestDiffNumbersAndTypes(t *testing.T) {
+	left, err := ParseDocument([]byte(`{"a": 1, "b": [1, 2], "c": {"d": true}, "$reserved": {"ETag": "x"}}`))
+	require.NoError(t, err)
+	right, err := ParseDocument([]byte(`{"a": 1.0, "b": [1], "c": "d", "$reserved": {"ETag": "y"}}`))
+	require.NoError(t, err)
+
+	changes, _, err := Diff(left, right, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []DiffChange{
+		{Path: "b[1]", Op: DiffRemoved, Left: json.Number("2")},
+		{Path: "c", Op: DiffChanged, Left: map[string]interface{}{"d": true}, Right: "d"},
+	}, changes)
+
+	_, err = ParseDocument([]byte(`{"a":`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,332 @@
+package json
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an output format of a JSON document
+type Format string
+
+const (
+	FormatJSON       Format = "json"
+	FormatPrettyJSON Format = "pretty"
+	FormatYAML       Format = "yaml"
+	FormatCSV        Format = "csv"
+	FormatMarkdown   Format = "markdown"
+)
+
+// Formats lists the supported output formats, the first one being the default
+var Formats = []Format{FormatJSON, FormatPrettyJSON, FormatYAML, FormatCSV, FormatMarkdown}
+
+// entityArrayKeys are the top-level fields holding the entities of a list response, in order of preference.
+// They are the rows of a table and are encoded one at a time by CustomJSON.
+var entityArrayKeys = []string{"entities", "data"}
+
+// ParseFormat validates an output format name, defaulting to compact JSON
+func ParseFormat(raw string) (Format, error) {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	if raw == "" {
+		return FormatJSON, nil
+	}
+
+	for _, format := range Formats {
+		if string(format) == raw {
+			return format, nil
+		}
+	}
+
+	names := make([]string, len(Formats))
+	for i, format := range Formats {
+		names[i] = string(format)
+	}
+	return "", fmt.Errorf("unsupported format %q, expected one of %s", raw, strings.Join(names, ", "))
+}
+
+// IsTable reports whether the format renders the entities as table rows
+func (f Format) IsTable() bool {
+	return f == FormatCSV || f == FormatMarkdown
+}
+
+// Render renders a JSON document in the given format.
+// Tables have one row per entity and one column per field path; without columns
+// every scalar field of the entities becomes a column.
+func Render(data []byte, format Format, columns []string) (string, error) {
+	switch format {
+	case FormatJSON:
+		return string(data), nil
+	case FormatPrettyJSON:
+		var out bytes.Buffer
+		if err := json.Indent(&out, data, "", "  "); err != nil {
+			return "", err
+		}
+		return out.String(), nil
+	case FormatYAML:
+		value, err := decodeJSON(data)
+		if err != nil {
+			return "", err
+		}
+		out, err := yaml.Marshal(value)
+		if err != nil {
+			return "", fmt.Errorf("failed to render yaml: %w", err)
+		}
+		return string(out), nil
+	case FormatCSV, FormatMarkdown:
+		header, rows, err := RenderTable(data, format, columns)
+		if err != nil {
+			return "", err
+		}
+		return header + strings.Join(rows, ""), nil
+	default:
+		return "", fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// RenderTable renders a JSON document as a csv or markdown table. The header and every row
+// are returned separately, each ending with a newline, so the table can be split between
+// rows without cutting a record, e.g. a quoted csv cell spanning several lines.
+func RenderTable(data []byte, format Format, columns []string) (string, []string, error) {
+	if !format.IsTable() {
+		return "", nil, fmt.Errorf("format %q is not a table", format)
+	}
+
+	value, err := decodeJSON(data)
+	if err != nil {
+		return "", nil, err
+	}
+	rows := tableRows(value)
+	if len(columns) == 0 {
+		columns = leafColumns(rows)
+	}
+	if len(columns) == 0 {
+		return "", nil, nil
+	}
+	cells := tableCells(rows, columns)
+	if format == FormatCSV {
+		return renderCSV(columns, cells)
+	}
+	header, lines := renderMarkdown(columns, cells)
+	return header, lines, nil
+}
+
+// decodeJSON decodes a JSON document keeping integers as int64 so that they are not rendered as floats
+func decodeJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return normalizeNumbers(value), nil
+}
+
+func normalizeNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalizeNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeNumbers(item)
+		}
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	}
+
+	return value
+}
+
+// tableRows returns the entities of a list response, a top-level array, or the document itself as a single row
+func tableRows(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case map[string]interface{}:
+		for _, key := range entityArrayKeys {
+			if rows, ok := v[key].([]interface{}); ok {
+				return rows
+			}
+		}
+	}
+
+	return []interface{}{value}
+}
+
+// leafColumns returns the paths of all scalar fields of the rows, sorted
+func leafColumns(rows []interface{}) []string {
+	seen := map[string]struct{}{}
+	for _, row := range rows {
+		collectLeafPaths(row, "", seen)
+	}
+
+	columns := make([]string, 0, len(seen))
+	for column := range seen {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	return columns
+}
+
+func collectLeafPaths(value interface{}, prefix string, seen map[string]struct{}) {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		if prefix != "" {
+			seen[prefix] = struct{}{}
+		}
+		return
+	}
+
+	for key, item := range object {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		collectLeafPaths(item, path, seen)
+	}
+}
+
+func tableCells(rows []interface{}, columns []string) [][]string {
+	cells := make([][]string, len(rows))
+	for i, row := range rows {
+		cells[i] = make([]string, len(columns))
+		for j, column := range columns {
+			cells[i][j] = cellText(lookupPath(row, strings.Split(column, ".")))
+		}
+	}
+
+	return cells
+}
+
+// lookupPath returns the value at a field path, collecting the values of [] and * segments into a list
+func lookupPath(value interface{}, segments []string) interface{} {
+	if value == nil || len(segments) == 0 {
+		return value
+	}
+
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	key := strings.TrimSuffix(segments[0], "[]")
+	var items []interface{}
+	switch {
+	case key == "*":
+		// A wildcard collects the values of every key, sorted by key
+		keys := make([]string, 0, len(object))
+		for k := range object {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			items = append(items, object[k])
+		}
+	case key == segments[0]:
+		return lookupPath(object[key], segments[1:])
+	default:
+		if items, ok = object[key].([]interface{}); !ok {
+			return nil
+		}
+	}
+	values := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		if v := lookupPath(item, segments[1:]); v != nil {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+func cellText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []interface{}:
+		texts := make([]string, len(v))
+		for i, item := range v {
+			texts[i] = cellText(item)
+		}
+		return strings.Join(texts, ", ")
+	case map[string]interface{}:
+		data, _ := json.Marshal(v)
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func renderCSV(columns []string, cells [][]string) (string, []string, error) {
+	header, err := csvRecord(columns)
+	if err != nil {
+		return "", nil, err
+	}
+
+	rows := make([]string, len(cells))
+	for i, row := range cells {
+		if rows[i], err = csvRecord(row); err != nil {
+			return "", nil, err
+		}
+	}
+
+	return header, rows, nil
+}
+
+func csvRecord(record []string) (string, error) {
+	var out bytes.Buffer
+	writer := csv.NewWriter(&out)
+	if err := writer.Write(record); err != nil {
+		return "", fmt.Errorf("failed to render csv: %w", err)
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", fmt.Errorf("failed to render csv: %w", err)
+	}
+
+	return out.String(), nil
+}
+
+func renderMarkdown(columns []string, cells [][]string) (string, []string) {
+	separators := make([]string, len(columns))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	header := markdownRow(columns) + markdownRow(separators)
+
+	rows := make([]string, len(cells))
+	for i, row := range cells {
+		rows[i] = markdownRow(row)
+	}
+
+	return header, rows
+}
+
+func markdownRow(cells []string) string {
+	var out strings.Builder
+	out.WriteString("|")
+	for _, cell := range cells {
+		cell = strings.ReplaceAll(cell, "|", "\\|")
+		cell = strings.ReplaceAll(strings.ReplaceAll(cell, "\r", ""), "\n", "<br>")
+		out.WriteString(" ")
+		out.WriteString(cell)
+		out.WriteString(" |")
+	}
+	out.WriteString("\n")
+	return out.String()
+}
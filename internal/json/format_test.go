@@ -0,0 +1,60 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const formatInput = `{"entities":[` +
+	`{"metadata":{"uuid":"1"},"spec":{"name":"web|01","resources":{"memory_size_mib":4096,"nic_list":[{"ip":"10.0.0.1"},{"ip":"10.0.0.2"}]}}},` +
+	`{"metadata":{"uuid":"2"},"spec":{"name":"db-01","resources":{"memory_size_mib":8192}}}` +
+	`],"metadata":{"total_matches":2}}`
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("")
+	require.NoError(t, err)
+	assert.Equal(t, FormatJSON, format)
+
+	format, err = ParseFormat(" Markdown ")
+	require.NoError(t, err)
+	assert.Equal(t, FormatMarkdown, format)
+	assert.True(t, format.IsTable())
+
+	_, err = ParseFormat("xml")
+	assert.ErrorContains(t, err, "json, pretty, yaml, csv, markdown")
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	out, err := Render([]byte(formatInput), FormatMarkdown, []string{"spec.name", "spec.resources.memory_size_mib", "spec.resources.nic_list[].ip"})
+	require.NoError(t, err)
+	assert.Equal(t, "| spec.name | spec.resources.memory_size_mib | spec.resources.nic_list[].ip |\n"+
+		"| --- | --- | --- |\n"+
+		"| web\\|01 | 4096 | 10.0.0.1, 10.0.0.2 |\n"+
+		"| db-01 | 8192 |  |\n", out)
+}
+
+func TestRenderTableWildcardColumn(t *testing.T) {
+	out, err := Render([]byte(`[{"a":{"x":1,"y":2}}]`), FormatCSV, []string{"a.*"})
+	require.NoError(t, err)
+	assert.Equal(t, "a.*\n\"1, 2\"\n", out)
+}
+
+func TestRenderCSVWithLeafColumns(t *testing.T) {
+	out, err := Render([]byte(formatInput), FormatCSV, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "metadata.uuid,spec.name,spec.resources.memory_size_mib,spec.resources.nic_list\n"+
+		"1,web|01,4096,\"{\"\"ip\"\":\"\"10.0.0.1\"\"}, {\"\"ip\"\":\"\"10.0.0.2\"\"}\"\n"+
+		"2,db-01,8192,\n", out)
+}
+
+func TestRenderYAMLAndPrettyJSON(t *testing.T) {
+	out, err := Render([]byte(`{"size":10737418240,"ratio":0.5,"name":"vm"}`), FormatYAML, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "name: vm\nratio: 0.5\nsize: 10737418240\n", out)
+
+	out, err = Render([]byte(`{"a":[1]}`), FormatPrettyJSON, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"a\": [\n    1\n  ]\n}", out)
+}
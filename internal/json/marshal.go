@@ -1,11 +1,8 @@
 package json
 
 import (
+	"bytes"
 	"encoding/json"
-	"fmt"
-	"strings"
-
-	"github.com/itchyny/gojq"
 )
 
 // DefaultStripPaths is a list of default paths to strip from the JSON output.
@@ -19,65 +16,31 @@ var DefaultStripPaths = []string{
 	"entities[].status.resources.guest_customization",
 }
 
-func stripProperties(data []byte, paths []string) ([]byte, error) {
-	var input interface{}
-	if err := json.Unmarshal(data, &input); err != nil {
-		return nil, err
-	}
-
-	// Start with identity query
-	queryStr := "."
-
-	for _, path := range paths {
-		if strings.Contains(path, "[]") {
-			// Handle array paths (entities[].status)
-			parts := strings.Split(path, "[]")
-			arrayPath := parts[0]
-			fieldPath := parts[1]
-
-			if len(fieldPath) > 0 && fieldPath[0] == '.' {
-				fieldPath = fieldPath[1:] // Remove leading dot
-			}
-
-			// Correct jq syntax for modifying each element in an array
-			queryStr += fmt.Sprintf(" | .%s |= map(del(.%s))", arrayPath, fieldPath)
-		} else {
-			// Simple path (api_version)
-			queryStr += fmt.Sprintf(" | del(.%s)", path)
-		}
-	}
-
-	// For debugging
-	// fmt.Printf("JQ Query: %s\n", queryStr)
-
-	query, err := gojq.Parse(queryStr)
-	if err != nil {
-		return nil, fmt.Errorf("jq parse error: %v for query: %s", err, queryStr)
-	}
-
-	code, err := gojq.Compile(query)
-	if err != nil {
-		return nil, fmt.Errorf("jq compile error: %v", err)
-	}
-
-	iter := code.Run(input)
-	result, ok := iter.Next()
-	if !ok {
-		return nil, fmt.Errorf("jq query returned no results")
-	}
-
-	if err, ok := result.(error); ok {
-		return nil, fmt.Errorf("jq execution error: %v", err)
-	}
-
-	return json.Marshal(result)
-}
+// Paths of StripPaths and Fields follow the same grammar:
+//
+//	spec.resources.guest_customization   nested keys
+//	entities[].spec.disk_list[].uuid     every element of (nested) arrays
+//	spec.*.uuid                          every key of an object
+//	..guest_customization                the key at any depth
+//
+// Keys may contain letters, digits, '_' and '-'. Each set of paths is compiled once.
 
 type CustomJSON struct {
-	Value      interface{}
+	Value interface{}
+	// StripPaths are deleted from the output
 	StripPaths []string
+	// Fields, when set, keeps only the values at the given paths (allowlist mode)
+	Fields []string
+	// EntityURI, when set, returns the resource URI of an entity of a list response. It is called
+	// before the entity is shaped and the URI is added as EntityURIKey, so it survives stripping and projection.
+	EntityURI func(entity map[string]interface{}) string `json:"-"`
+	// Redactions counts the secrets masked by the last MarshalJSON or Encode
+	Redactions RedactionCounts
 }
 
+// EntityURIKey is the field holding the resource URI of every entity of a list response
+const EntityURIKey = "uri"
+
 type RegularJSON struct {
 	Value interface{}
 }
@@ -89,6 +52,26 @@ func CustomJSONEncoder(value any) *CustomJSON {
 	}
 }
 
+// ProjectedJSONEncoder returns an encoder that strips the default paths and
+// keeps only the given field paths
+func ProjectedJSONEncoder(value any, fields []string) *CustomJSON {
+	return &CustomJSON{
+		Value:      value,
+		StripPaths: DefaultStripPaths,
+		Fields:     fields,
+	}
+}
+
+// StrippedJSONEncoder returns an encoder that strips the given paths, e.g. those of a
+// strip profile, and keeps only the given field paths when there are any
+func StrippedJSONEncoder(value any, stripPaths []string, fields []string) *CustomJSON {
+	return &CustomJSON{
+		Value:      value,
+		StripPaths: stripPaths,
+		Fields:     fields,
+	}
+}
+
 func RegularJSONEncoder(value any) *RegularJSON {
 	return &RegularJSON{
 		Value: value,
@@ -105,10 +88,10 @@ func (r *RegularJSON) MarshalJSON() ([]byte, error) {
 }
 
 func (d *CustomJSON) MarshalJSON() ([]byte, error) {
-	data, err := json.Marshal(d.Value)
-	if err != nil {
+	var buf bytes.Buffer
+	if err := d.Encode(&buf); err != nil {
 		return nil, err
 	}
 
-	return stripProperties(data, d.StripPaths)
+	return buf.Bytes(), nil
 }
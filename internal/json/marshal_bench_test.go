@@ -0,0 +1,233 @@
+package json
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+)
+
+// vmListFixture builds a ListAllVM response of n VMs shaped like those returned by Prism Central,
+// each with disks, NICs, categories and a cloud-init payload
+func vmListFixture(n int) *v3.VMListIntentResponse {
+	str := func(s string) *string { return &s }
+	num := func(i int64) *int64 { return &i }
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	userData := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("#cloud-config\npackages: [nginx]\n", 40)))
+
+	total := int64(n)
+	list := &v3.VMListIntentResponse{
+		APIVersion: str("3.1"),
+		Metadata:   &v3.ListMetadataOutput{Kind: str("vm"), Length: num(int64(n)), TotalMatches: &total},
+		Entities:   make([]*v3.VMIntentResource, n),
+	}
+
+	for i := range list.Entities {
+		name := fmt.Sprintf("vm-%05d", i)
+		disks := make([]*v3.VMDisk, 3)
+		for j := range disks {
+			disks[j] = &v3.VMDisk{
+				UUID:                str(fmt.Sprintf("disk-%05d-%d", i, j)),
+				DiskSizeBytes:       num(int64(j+1) << 34),
+				DiskSizeMib:         num(int64(j+1) << 14),
+				DeviceProperties:    &v3.VMDiskDeviceProperties{DeviceType: str("DISK"), DiskAddress: &v3.DiskAddress{AdapterType: str("SCSI"), DeviceIndex: num(int64(j))}},
+				DataSourceReference: &v3.Reference{Kind: str("image"), UUID: str(fmt.Sprintf("image-%d", j)), Name: str("centos")},
+			}
+		}
+		nics := make([]*v3.VMNicOutputStatus, 2)
+		for j := range nics {
+			nics[j] = &v3.VMNicOutputStatus{
+				UUID:            str(fmt.Sprintf("nic-%05d-%d", i, j)),
+				MacAddress:      str(fmt.Sprintf("50:6b:8d:00:%02x:%02x", i%256, j)),
+				SubnetReference: &v3.Reference{Kind: str("subnet"), UUID: str(fmt.Sprintf("subnet-%d", j)), Name: str("vlan0")},
+				IPEndpointList:  []*v3.IPAddress{{IP: str(fmt.Sprintf("10.%d.%d.%d", j, i/256%256, i%256)), Type: str("ASSIGNED")}},
+			}
+		}
+
+		list.Entities[i] = &v3.VMIntentResource{
+			Metadata: &v3.Metadata{
+				Kind:         str("vm"),
+				UUID:         str(fmt.Sprintf("00000000-0000-0000-0000-%012d", i)),
+				CreationTime: &created,
+				SpecVersion:  num(3),
+				Categories:   map[string]string{"Environment": "Production", "AppType": "web"},
+			},
+			Spec: &v3.VM{
+				Name:             str(name),
+				ClusterReference: &v3.Reference{Kind: str("cluster"), UUID: str("cluster-1"), Name: str("cluster-a")},
+				Resources: &v3.VMResources{
+					NumSockets:        num(2),
+					NumVcpusPerSocket: num(4),
+					MemorySizeMib:     num(8192),
+					PowerState:        str("ON"),
+					DiskList:          disks,
+					GuestCustomization: &v3.GuestCustomization{
+						CloudInit: &v3.GuestCustomizationCloudInit{UserData: str(userData)},
+					},
+				},
+			},
+			Status: &v3.VMDefStatus{
+				Name:             str(name),
+				State:            str("COMPLETE"),
+				ClusterReference: &v3.Reference{Kind: str("cluster"), UUID: str("cluster-1"), Name: str("cluster-a")},
+				Resources: &v3.VMResourcesDefStatus{
+					NumSockets:        num(2),
+					NumVcpusPerSocket: num(4),
+					MemorySizeMib:     num(8192),
+					PowerState:        str("ON"),
+					HostReference:     &v3.Reference{Kind: str("host"), UUID: str("host-1"), Name: str("host-a")},
+					DiskList:          disks,
+					NicList:           nics,
+				},
+			},
+		}
+	}
+
+	return list
+}
+
+// peakHeap runs f and returns the highest heap in use while it ran, above the heap in use before.
+// The heap is sampled every millisecond, so short-lived peaks may be missed.
+func peakHeap(b *testing.B, f func()) uint64 {
+	var stats runtime.MemStats
+	b.StopTimer()
+	runtime.GC()
+	runtime.ReadMemStats(&stats)
+	base := stats.HeapInuse
+	b.StartTimer()
+
+	var (
+		wg   sync.WaitGroup
+		peak uint64
+		done = make(chan struct{})
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(time.Millisecond)
+		defer ticker.Stop()
+		var sample runtime.MemStats
+		for {
+			runtime.ReadMemStats(&sample)
+			if sample.HeapInuse > base && sample.HeapInuse-base > peak {
+				peak = sample.HeapInuse - base
+			}
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	f()
+	close(done)
+	wg.Wait()
+
+	return peak
+}
+
+// wholeDocumentJSON encodes a value the way CustomJSON did before entities were streamed:
+// the whole response is decoded into a tree, shaped and encoded at once
+func wholeDocumentJSON(d *CustomJSON) ([]byte, error) {
+	strip, err := cachedPathFilter(FilterDelete, d.StripPaths)
+	if err != nil {
+		return nil, err
+	}
+	keep, err := cachedPathFilter(FilterKeep, d.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(d.Value)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if value, err = d.shape(value, strip, keep); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(value)
+}
+
+// Run with: go test ./internal/json -run '^$' -bench MarshalJSON -benchmem
+//
+// peak-MB/op is the peak heap in use above the fixture while encoding, which streaming entities is meant to bound;
+// the WholeDocument benchmarks give the baseline.
+func benchmarkMarshalJSON(b *testing.B, encoder func(value interface{}) *CustomJSON, marshal func(d *CustomJSON) ([]byte, error)) {
+	fixture := vmListFixture(10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	var peak uint64
+	for i := 0; i < b.N; i++ {
+		var (
+			data []byte
+			err  error
+		)
+		opPeak := peakHeap(b, func() {
+			data, err = marshal(encoder(fixture))
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if opPeak > peak {
+			peak = opPeak
+		}
+		b.SetBytes(int64(len(data)))
+	}
+	b.ReportMetric(float64(peak)/(1<<20), "peak-MB/op")
+}
+
+func streamedJSON(d *CustomJSON) ([]byte, error) {
+	return d.MarshalJSON()
+}
+
+func defaultStrip(value interface{}) *CustomJSON {
+	return CustomJSONEncoder(value)
+}
+
+func fields(value interface{}) *CustomJSON {
+	return ProjectedJSONEncoder(value, []string{"metadata", "entities[].spec.name", "entities[].status.resources.nic_list[].ip_endpoint_list[].ip"})
+}
+
+func recursiveStrip(value interface{}) *CustomJSON {
+	return StrippedJSONEncoder(value, []string{"..guest_customization", "entities[].spec"}, nil)
+}
+
+func BenchmarkMarshalJSONDefaultStrip10kVMs(b *testing.B) {
+	benchmarkMarshalJSON(b, defaultStrip, streamedJSON)
+}
+
+func BenchmarkMarshalJSONDefaultStripWholeDocument10kVMs(b *testing.B) {
+	benchmarkMarshalJSON(b, defaultStrip, wholeDocumentJSON)
+}
+
+func BenchmarkMarshalJSONFields10kVMs(b *testing.B) {
+	benchmarkMarshalJSON(b, fields, streamedJSON)
+}
+
+func BenchmarkMarshalJSONFieldsWholeDocument10kVMs(b *testing.B) {
+	benchmarkMarshalJSON(b, fields, wholeDocumentJSON)
+}
+
+func BenchmarkMarshalJSONRecursiveStrip10kVMs(b *testing.B) {
+	benchmarkMarshalJSON(b, recursiveStrip, streamedJSON)
+}
+
+func BenchmarkMarshalJSONRecursiveStripWholeDocument10kVMs(b *testing.B) {
+	benchmarkMarshalJSON(b, recursiveStrip, wholeDocumentJSON)
+}
@@ -2,8 +2,10 @@ package json
 
 import (
 	"encoding/json"
-	"github.com/stretchr/testify/assert"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMarshalJSON(t *testing.T) {
@@ -53,3 +55,122 @@ func TestMarshalJSON(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, mdata)
 }
+
+func TestMarshalJSONWithFields(t *testing.T) {
+	value := map[string]any{
+		"api_version": "3.1",
+		"metadata":    map[string]any{"total_matches": 2},
+		"entities": []any{
+			map[string]any{
+				"metadata": map[string]any{"uuid": "uuid-1"},
+				"spec":     map[string]any{"name": "vm1", "resources": map[string]any{"num_sockets": 2}},
+				"status":   map[string]any{"resources": map[string]any{"power_state": "ON"}},
+			},
+			map[string]any{
+				"metadata": map[string]any{"uuid": "uuid-2"},
+				"spec":     map[string]any{"name": "vm2"},
+			},
+		},
+	}
+
+	cjson := ProjectedJSONEncoder(value, []string{"metadata", "entities[].spec.name", "entities[].status.resources.power_state"})
+	mdata, err := json.Marshal(cjson)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"metadata": {"total_matches": 2},
+		"entities": [
+			{"spec": {"name": "vm1"}, "status": {"resources": {"power_state": "ON"}}},
+			{"spec": {"name": "vm2"}}
+		]
+	}`, string(mdata))
+}
+
+func TestParseFieldList(t *testing.T) {
+	fields, err := ParseFieldList("spec.name, metadata.uuid,,status.resources.nic_list[].ip_endpoint_list")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"spec.name", "metadata.uuid", "status.resources.nic_list[].ip_endpoint_list"}, fields)
+
+	_, err = ParseFieldList("spec..name")
+	assert.Error(t, err)
+
+	_, err = ParseFieldList("spec.name | del(.)")
+	assert.Error(t, err)
+}
+
+func TestEncodeStreamsListResponses(t *testing.T) {
+	fixture := vmListFixture(5)
+	_, ok, err := splitEntityList(fixture)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	tests := []struct {
+		strip  []string
+		fields []string
+	}{
+		{strip: DefaultStripPaths},
+		{strip: []string{"..guest_customization", "entities[].status.resources.nic_list[].ip_endpoint_list"}},
+		{strip: []string{"metadata.kind", "entities..uuid"}},
+		{strip: []string{"entities[]"}},
+		{strip: []string{"entities"}, fields: []string{"metadata"}},
+		{strip: DefaultStripPaths, fields: []string{"metadata", "entities[].spec.name", "entities[].status.resources.nic_list[].ip_endpoint_list[].ip"}},
+		{fields: []string{"entities[].metadata.categories.*", "..power_state"}},
+		{fields: []string{"entities[]"}},
+		{strip: []string{"*.kind"}, fields: []string{"*"}},
+	}
+
+	for _, test := range tests {
+		streamed, err := StrippedJSONEncoder(fixture, test.strip, test.fields).MarshalJSON()
+		require.NoError(t, err, test)
+
+		// Shape the whole document at once for comparison
+		strip, err := CompilePathFilter(FilterDelete, test.strip)
+		require.NoError(t, err)
+		keep, err := CompilePathFilter(FilterKeep, test.fields)
+		require.NoError(t, err)
+		value, err := decodeValue(fixture)
+		require.NoError(t, err)
+		cjson := &CustomJSON{Redactions: RedactionCounts{}}
+		value, err = cjson.shape(value, strip, keep)
+		require.NoError(t, err)
+		whole, err := json.Marshal(value)
+		require.NoError(t, err)
+
+		assert.JSONEq(t, string(whole), string(streamed), "%+v", test)
+	}
+}
+
+func TestEncodeListRedactsEachEntity(t *testing.T) {
+	cjson := StrippedJSONEncoder(vmListFixture(3), nil, nil)
+	data, err := cjson.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, RedactionCounts{"cloud_init": 3}, cjson.Redactions)
+	assert.NotContains(t, string(data), "I2Nsb3Vk")
+}
+
+func TestMarshalJSONAddsEntityURIs(t *testing.T) {
+	value := map[string]any{
+		"data": []any{
+			map[string]any{"extId": "vm-1", "name": "web-01", "tenantId": "t"},
+			map[string]any{"name": "orphan"},
+		},
+		"metadata": map[string]any{"totalAvailableResults": 2},
+	}
+	uri := func(entity map[string]interface{}) string {
+		if id, ok := entity["extId"].(string); ok {
+			return "vmm_vm://" + id
+		}
+		return ""
+	}
+
+	// Entities shaped one at a time
+	cjson := &CustomJSON{Value: value, StripPaths: []string{"data[].tenantId"}, EntityURI: uri}
+	data, err := cjson.MarshalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data":[{"extId":"vm-1","name":"web-01","uri":"vmm_vm://vm-1"},{"name":"orphan"}],"metadata":{"totalAvailableResults":2}}`, string(data))
+
+	// The document shaped as a whole, paths starting with * cannot be split per entity
+	cjson = &CustomJSON{Value: value, Fields: []string{"*[].name"}, EntityURI: uri}
+	data, err = cjson.MarshalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data":[{"name":"web-01","uri":"vmm_vm://vm-1"},{"name":"orphan"}]}`, string(data))
+}
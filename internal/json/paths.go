@@ -0,0 +1,335 @@
+package json
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// pathSegmentPattern matches a single segment of a path: a key or a * wildcard,
+// followed by any number of [] array iterations, e.g. disk_list[] or *
+var pathSegmentPattern = regexp.MustCompile(`^(\*|[A-Za-z0-9_-]+)?((?:\[\])*)$`)
+
+// pathSegment is a parsed segment of a path
+type pathSegment struct {
+	// Recursive matches the segment at any depth below the previous one (..key)
+	Recursive bool
+	// Key is the object key, empty for a bare [] segment
+	Key string
+	// Wildcard matches every key of an object (*)
+	Wildcard bool
+	// Arrays is the number of [] iterations following the key
+	Arrays int
+}
+
+// parsePath parses a path such as entities[].spec.resources.disk_list[].data_source_reference,
+// spec.*.uuid or ..guest_customization. Recursive descent (..) is only accepted when allowRecursive is set.
+func parsePath(path string, allowRecursive bool) ([]pathSegment, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, fmt.Errorf("path must not be empty")
+	}
+
+	var segments []pathSegment
+	for i, chunk := range strings.Split(path, "..") {
+		recursive := i > 0
+		if recursive && !allowRecursive {
+			return nil, fmt.Errorf("invalid path %q: recursive descent (..) is not supported here", path)
+		}
+		if chunk == "" {
+			if i == 0 {
+				// A leading .. descends from the root
+				continue
+			}
+			return nil, fmt.Errorf("invalid path %q: .. must be followed by a key", path)
+		}
+
+		for j, raw := range strings.Split(chunk, ".") {
+			match := pathSegmentPattern.FindStringSubmatch(raw)
+			if raw == "" || match == nil {
+				return nil, fmt.Errorf("invalid path %q: segment %q must be a key of letters, digits, '_' or '-', or *, optionally followed by []", path, raw)
+			}
+			segments = append(segments, pathSegment{
+				Recursive: recursive && j == 0,
+				Key:       match[1],
+				Wildcard:  match[1] == "*",
+				Arrays:    len(match[2]) / 2,
+			})
+		}
+	}
+
+	return segments, nil
+}
+
+// pathStepKind is the kind of a single step of a path
+type pathStepKind int
+
+const (
+	// stepKey selects the value of a key of an object
+	stepKey pathStepKind = iota
+	// stepIterate selects every element of an array or every value of an object
+	stepIterate
+	// stepRecurse selects a value and all values below it
+	stepRecurse
+)
+
+// pathStep is a single step of a path, missing or mistyped values are skipped instead of aborting
+type pathStep struct {
+	Kind pathStepKind
+	Key  string
+}
+
+// pathSteps converts parsed segments into the steps walked over a decoded JSON value
+func pathSteps(segments []pathSegment) []pathStep {
+	steps := make([]pathStep, 0, len(segments))
+	for _, segment := range segments {
+		if segment.Recursive {
+			steps = append(steps, pathStep{Kind: stepRecurse})
+		}
+		switch {
+		case segment.Wildcard:
+			steps = append(steps, pathStep{Kind: stepIterate})
+		case segment.Key != "":
+			steps = append(steps, pathStep{Kind: stepKey, Key: segment.Key})
+		}
+		for i := 0; i < segment.Arrays; i++ {
+			steps = append(steps, pathStep{Kind: stepIterate})
+		}
+	}
+
+	return steps
+}
+
+// FilterMode decides what a PathFilter does with the values matching its paths
+type FilterMode int
+
+const (
+	// FilterDelete removes the matching values
+	FilterDelete FilterMode = iota
+	// FilterKeep keeps only the matching values
+	FilterKeep
+)
+
+// PathFilter is a set of paths compiled once into steps that delete or keep the matching values
+type PathFilter struct {
+	Mode  FilterMode
+	Paths []string
+	steps [][]pathStep
+}
+
+// CompilePathFilter validates the paths and compiles them into a filter
+func CompilePathFilter(mode FilterMode, paths []string) (*PathFilter, error) {
+	if mode != FilterDelete && mode != FilterKeep {
+		return nil, fmt.Errorf("unknown filter mode %d", mode)
+	}
+
+	filter := &PathFilter{Mode: mode, Paths: paths}
+	for _, path := range paths {
+		segments, err := parsePath(path, true)
+		if err != nil {
+			return nil, err
+		}
+		filter.steps = append(filter.steps, pathSteps(segments))
+	}
+
+	return filter, nil
+}
+
+// Empty reports whether the filter leaves values unchanged
+func (f *PathFilter) Empty() bool {
+	return len(f.steps) == 0
+}
+
+// Apply runs the filter on a decoded JSON value. Deleting modifies the value in place,
+// keeping returns a new value sharing the kept values with the input.
+func (f *PathFilter) Apply(value interface{}) (interface{}, error) {
+	if f.Empty() {
+		return value, nil
+	}
+
+	if f.Mode == FilterDelete {
+		for _, steps := range f.steps {
+			value = deletePath(value, steps)
+		}
+		return value, nil
+	}
+
+	// Copy the concrete paths of every match that has a value into an empty document
+	var kept interface{}
+	for _, steps := range f.steps {
+		walkPath(value, steps, nil, func(path []interface{}, match interface{}) {
+			if match != nil {
+				kept = setPath(kept, path, match)
+			}
+		})
+	}
+	if kept == nil {
+		if _, ok := value.([]interface{}); ok {
+			return []interface{}{}, nil
+		}
+		return map[string]interface{}{}, nil
+	}
+
+	return kept, nil
+}
+
+// deletePath deletes the values matching the steps and returns the resulting value.
+// Iterating in the last step empties the array or object.
+func deletePath(value interface{}, steps []pathStep) interface{} {
+	if len(steps) == 0 {
+		return value
+	}
+	step, rest := steps[0], steps[1:]
+
+	switch step.Kind {
+	case stepKey:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		child, ok := object[step.Key]
+		if !ok {
+			return value
+		}
+		if len(rest) == 0 {
+			delete(object, step.Key)
+		} else {
+			object[step.Key] = deletePath(child, rest)
+		}
+	case stepIterate:
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for key, child := range v {
+				if len(rest) == 0 {
+					delete(v, key)
+				} else {
+					v[key] = deletePath(child, rest)
+				}
+			}
+		case []interface{}:
+			if len(rest) == 0 {
+				return []interface{}{}
+			}
+			for i, child := range v {
+				v[i] = deletePath(child, rest)
+			}
+		}
+	case stepRecurse:
+		value = deletePath(value, rest)
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for key, child := range v {
+				v[key] = deletePath(child, steps)
+			}
+		case []interface{}:
+			for i, child := range v {
+				v[i] = deletePath(child, steps)
+			}
+		}
+	}
+
+	return value
+}
+
+// walkPath calls fn with the concrete path and value of every match of the steps
+func walkPath(value interface{}, steps []pathStep, path []interface{}, fn func(path []interface{}, value interface{})) {
+	if len(steps) == 0 {
+		fn(path, value)
+		return
+	}
+	step, rest := steps[0], steps[1:]
+
+	switch step.Kind {
+	case stepKey:
+		if object, ok := value.(map[string]interface{}); ok {
+			if child, ok := object[step.Key]; ok {
+				walkPath(child, rest, append(path, step.Key), fn)
+			}
+		}
+	case stepIterate:
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for key, child := range v {
+				walkPath(child, rest, append(path, key), fn)
+			}
+		case []interface{}:
+			for i, child := range v {
+				walkPath(child, rest, append(path, i), fn)
+			}
+		}
+	case stepRecurse:
+		walkPath(value, rest, path, fn)
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for key, child := range v {
+				walkPath(child, steps, append(path, key), fn)
+			}
+		case []interface{}:
+			for i, child := range v {
+				walkPath(child, steps, append(path, i), fn)
+			}
+		}
+	}
+}
+
+// setPath sets the value at a concrete path, creating objects for keys and
+// null-padded arrays for indexes along the way
+func setPath(root interface{}, path []interface{}, value interface{}) interface{} {
+	if len(path) == 0 {
+		return value
+	}
+
+	switch key := path[0].(type) {
+	case string:
+		object, ok := root.(map[string]interface{})
+		if !ok {
+			object = map[string]interface{}{}
+		}
+		object[key] = setPath(object[key], path[1:], value)
+		return object
+	case int:
+		array, _ := root.([]interface{})
+		for len(array) <= key {
+			array = append(array, nil)
+		}
+		array[key] = setPath(array[key], path[1:], value)
+		return array
+	}
+
+	return root
+}
+
+// maxCachedPathFilters bounds the cache, as field lists come from tool arguments
+const maxCachedPathFilters = 256
+
+// pathFilters caches compiled filters so that each set of paths is compiled only once
+var (
+	pathFilters      sync.Map
+	pathFiltersCount atomic.Int64
+)
+
+type pathFilterKey struct {
+	mode  FilterMode
+	paths string
+}
+
+// cachedPathFilter returns the compiled filter of the paths, compiling it on first use
+func cachedPathFilter(mode FilterMode, paths []string) (*PathFilter, error) {
+	key := pathFilterKey{mode: mode, paths: strings.Join(paths, "\x00")}
+	if filter, ok := pathFilters.Load(key); ok {
+		return filter.(*PathFilter), nil
+	}
+
+	filter, err := CompilePathFilter(mode, paths)
+	if err != nil {
+		return nil, err
+	}
+	if pathFiltersCount.Load() < maxCachedPathFilters {
+		if _, loaded := pathFilters.LoadOrStore(key, filter); !loaded {
+			pathFiltersCount.Add(1)
+		}
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,110 @@
+package json
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pathsInput = `{
+	"api_version": "3.1",
+	"entities": [
+		{
+			"spec": {
+				"name": "vm1",
+				"resources": {
+					"guest_customization": {"cloud_init": "secret"},
+					"disk_list": [
+						{"uuid": "d1", "data_source_reference": {"kind": "image", "uuid": "i1"}},
+						{"uuid": "d2"}
+					]
+				}
+			},
+			"status": {"resources": {"guest_customization": {"sysprep": "secret"}}},
+			"metadata": {"categories": {"app-type": "web"}}
+		}
+	],
+	"matrix": [[{"a": 1, "b": 2}], [{"a": 3, "b": 4}]]
+}`
+
+func applyFilter(t *testing.T, mode FilterMode, paths []string) string {
+	var value interface{}
+	require.NoError(t, json.Unmarshal([]byte(pathsInput), &value))
+
+	filter, err := CompilePathFilter(mode, paths)
+	require.NoError(t, err)
+	result, err := filter.Apply(value)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestPathFilterDelete(t *testing.T) {
+	out := applyFilter(t, FilterDelete, []string{
+		"api_version",
+		"entities[].spec.resources.disk_list[].data_source_reference",
+		"entities[].metadata.categories.app-type",
+		"..guest_customization",
+		"matrix[][].b",
+		"missing[].key",
+	})
+
+	assert.JSONEq(t, `{
+		"entities": [
+			{
+				"spec": {"name": "vm1", "resources": {"disk_list": [{"uuid": "d1"}, {"uuid": "d2"}]}},
+				"status": {"resources": {}},
+				"metadata": {"categories": {}}
+			}
+		],
+		"matrix": [[{"a": 1}], [{"a": 3}]]
+	}`, out)
+}
+
+func TestPathFilterKeep(t *testing.T) {
+	out := applyFilter(t, FilterKeep, []string{"entities[].spec.name", "entities[].*.resources.disk_list[].uuid"})
+	assert.JSONEq(t, `{
+		"entities": [
+			{"spec": {"name": "vm1", "resources": {"disk_list": [{"uuid": "d1"}, {"uuid": "d2"}]}}}
+		]
+	}`, out)
+
+	out = applyFilter(t, FilterKeep, []string{"..cloud_init"})
+	assert.JSONEq(t, `{"entities": [{"spec": {"resources": {"guest_customization": {"cloud_init": "secret"}}}}]}`, out)
+
+	out = applyFilter(t, FilterKeep, []string{"nothing.here"})
+	assert.JSONEq(t, `{}`, out)
+}
+
+func TestParsePath(t *testing.T) {
+	segments, err := parsePath("..a.b[][].*", true)
+	require.NoError(t, err)
+	assert.Equal(t, []pathSegment{
+		{Recursive: true, Key: "a"},
+		{Key: "b", Arrays: 2},
+		{Key: "*", Wildcard: true},
+	}, segments)
+
+	for _, path := range []string{"", "a.", "a...b", "a..", "a.b c", "a[", "a.b | del(.)", `a."b"`} {
+		_, err := parsePath(path, true)
+		assert.Error(t, err, path)
+	}
+
+	_, err = parsePath("a..b", false)
+	assert.Error(t, err)
+}
+
+func TestCachedPathFilter(t *testing.T) {
+	first, err := cachedPathFilter(FilterDelete, DefaultStripPaths)
+	require.NoError(t, err)
+	second, err := cachedPathFilter(FilterDelete, DefaultStripPaths)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	_, err = cachedPathFilter(FilterDelete, []string{"bad path"})
+	assert.Error(t, err)
+}
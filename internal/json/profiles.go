@@ -0,0 +1,202 @@
+package json
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DetailMinimal drops the desired-state spec, keeping the observed status and metadata.
+	// For v4 resource types it drops the nested configuration of the entities.
+	DetailMinimal = "minimal"
+	// DetailDefault strips DefaultStripPaths
+	DetailDefault = "default"
+	// DetailFull strips nothing
+	DetailFull = "full"
+
+	// AnyResourceType holds the profiles applying to every resource type without its own profile
+	AnyResourceType = "*"
+)
+
+// StripProfiles maps a resource type to its named strip profiles, each a list of paths to strip.
+// The resource type "*" holds the profiles of every resource type without its own.
+type StripProfiles map[string]map[string][]string
+
+var (
+	stripProfilesMu sync.RWMutex
+	stripProfiles   = StripProfiles{}
+)
+
+// builtinStripProfiles are used when no configured profile matches
+func builtinStripProfiles() StripProfiles {
+	minimal := append(append([]string{}, DefaultStripPaths...), "spec", "entities[].spec")
+
+	profiles := StripProfiles{
+		AnyResourceType: {
+			DetailMinimal: minimal,
+			DetailDefault: DefaultStripPaths,
+			DetailFull:    {},
+		},
+	}
+
+	// v4 entities have no spec to drop, their minimal profile drops the nested configuration instead
+	for resourceType, fields := range v4MinimalStripFields {
+		paths := append([]string{}, DefaultStripPaths...)
+		for _, field := range append([]string{"links", "tenantId"}, fields...) {
+			paths = append(paths, "data[]."+field)
+		}
+		profiles[resourceType] = map[string][]string{DetailMinimal: paths}
+	}
+
+	return profiles
+}
+
+// v4MinimalStripFields are the entity fields dropped by the minimal profile of each v4 resource type,
+// in addition to links and tenantId
+var v4MinimalStripFields = map[string][]string{
+	"vmm_vm": {
+		"apcConfig", "bootConfig", "cdRoms", "disks", "enabledCpuFeatures", "gpus", "guestCustomization", "guestTools",
+		"nics", "ownershipInfo", "serialPorts", "source", "storageConfig", "vtpmConfig",
+	},
+	"vmm_image":            {"checksum", "clusterLocationExtIds", "placementPolicyStatus", "source"},
+	"clustermgmt_cluster":  {"config", "network", "nodes", "upgradeStatus"},
+	"networking_subnet":    {"dhcpOptions", "dynamicIpAddresses", "ipConfig", "ipUsage", "metadata", "reservedIpAddresses", "virtualSwitch", "vpc"},
+	"storage_container":    {"mappedRemoteContainers", "nfsWhitelistAddress", "vstoreNameList"},
+	"volumes_volume_group": {"enabledAuthentications", "iscsiFeatures", "storageFeatures"},
+}
+
+// LoadStripProfiles reads strip profiles from a YAML or JSON file such as
+//
+//	"*":
+//	  minimal: [spec, entities[].spec, ..guest_customization]
+//	cluster:
+//	  default: [entities[].status.resources.config.software_map]
+//
+// A configured profile replaces the built-in profile of the same name for its resource type.
+func LoadStripProfiles(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read strip profiles: %w", err)
+	}
+
+	profiles, err := ParseStripProfiles(data)
+	if err != nil {
+		return fmt.Errorf("invalid strip profiles in %s: %w", path, err)
+	}
+
+	SetStripProfiles(profiles)
+	return nil
+}
+
+// ParseStripProfiles parses and validates strip profiles
+func ParseStripProfiles(data []byte) (StripProfiles, error) {
+	profiles := StripProfiles{}
+	if err := yaml.Unmarshal(data, &profiles); err != nil {
+		return nil, err
+	}
+
+	for resourceType, named := range profiles {
+		for name, paths := range named {
+			if strings.TrimSpace(name) == "" {
+				return nil, fmt.Errorf("%s: profile name must not be empty", resourceType)
+			}
+			if _, err := CompilePathFilter(FilterDelete, paths); err != nil {
+				return nil, fmt.Errorf("%s profile %s: %w", resourceType, name, err)
+			}
+		}
+	}
+
+	return profiles, nil
+}
+
+// SetStripProfiles replaces the configured strip profiles
+func SetStripProfiles(profiles StripProfiles) {
+	stripProfilesMu.Lock()
+	defer stripProfilesMu.Unlock()
+
+	stripProfiles = profiles
+}
+
+// StripPathsFor returns the paths to strip for a resource type and detail level.
+// Profiles of the resource type take precedence over those of "*", and configured over built-in ones.
+// An empty detail selects the default profile.
+func StripPathsFor(resourceType string, detail string) ([]string, error) {
+	detail = strings.TrimSpace(detail)
+	if detail == "" {
+		detail = DetailDefault
+	}
+
+	stripProfilesMu.RLock()
+	defer stripProfilesMu.RUnlock()
+
+	for _, profiles := range []StripProfiles{stripProfiles, builtinStripProfiles()} {
+		for _, key := range []string{resourceType, AnyResourceType} {
+			if paths, ok := profiles[key][detail]; ok {
+				return paths, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("unknown detail %q for %s, available: %s", detail, resourceType, strings.Join(detailLevels(resourceType), ", "))
+}
+
+// entityPathPrefixes map the entity arrays of list responses to the entity of a read, e.g. of a resource:
+// v3 entities are the response itself, v4 entities the object under data
+var entityPathPrefixes = [][2]string{
+	{"entities[].", ""},
+	{"data[].", "data."},
+}
+
+// EntityStripPathsFor returns the paths to strip from a single entity for a resource type and detail level.
+// It looks up the same profile as StripPathsFor, so that a read returns the entity in the shape a list does.
+// The paths of the profile are kept, as sub-resources may list entities.
+func EntityStripPathsFor(resourceType string, detail string) ([]string, error) {
+	paths, err := StripPathsFor(resourceType, detail)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]struct{}{}
+	entityPaths := make([]string, 0, 2*len(paths))
+	add := func(path string) {
+		if _, ok := seen[path]; !ok {
+			seen[path] = struct{}{}
+			entityPaths = append(entityPaths, path)
+		}
+	}
+	for _, path := range paths {
+		add(path)
+		for _, prefix := range entityPathPrefixes {
+			if rest, ok := strings.CutPrefix(path, prefix[0]); ok && rest != "" {
+				add(prefix[1] + rest)
+			}
+		}
+	}
+
+	return entityPaths, nil
+}
+
+// detailLevels returns the sorted names of the profiles available for a resource type
+func detailLevels(resourceType string) []string {
+	seen := map[string]struct{}{}
+	for _, profiles := range []StripProfiles{stripProfiles, builtinStripProfiles()} {
+		for _, key := range []string{resourceType, AnyResourceType} {
+			for name := range profiles[key] {
+				seen[name] = struct{}{}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
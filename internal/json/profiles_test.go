@@ -0,0 +1,128 @@
+package json
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStripPathsForBuiltinProfiles(t *testing.T) {
+	SetStripProfiles(StripProfiles{})
+
+	paths, err := StripPathsFor("vm", "")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultStripPaths, paths)
+
+	paths, err = StripPathsFor("cluster", DetailFull)
+	require.NoError(t, err)
+	assert.Empty(t, paths)
+
+	paths, err = StripPathsFor("vm", DetailMinimal)
+	require.NoError(t, err)
+	assert.Contains(t, paths, "entities[].spec")
+
+	_, err = StripPathsFor("vm", "verbose")
+	assert.ErrorContains(t, err, "available: default, full, minimal")
+}
+
+func TestMinimalProfileShrinksV4Response(t *testing.T) {
+	SetStripProfiles(StripProfiles{})
+
+	response := map[string]interface{}{
+		"data": []interface{}{
+			map[string]interface{}{
+				"extId":      "vm-1",
+				"name":       "web-01",
+				"powerState": "ON",
+				"tenantId":   "tenant",
+				"links":      []interface{}{map[string]interface{}{"href": "https://pc/api/vmm/v4.0/ahv/config/vms/vm-1", "rel": "self"}},
+				"disks":      []interface{}{map[string]interface{}{"extId": "disk-1", "diskAddress": map[string]interface{}{"busType": "SCSI", "index": 0}}},
+				"nics":       []interface{}{map[string]interface{}{"extId": "nic-1", "networkInfo": map[string]interface{}{"subnet": map[string]interface{}{"extId": "subnet-1"}}}},
+			},
+		},
+		"metadata": map[string]interface{}{"totalAvailableResults": 1},
+	}
+
+	marshal := func(detail string) string {
+		paths, err := StripPathsFor("vmm_vm", detail)
+		require.NoError(t, err)
+		data, err := (&CustomJSON{Value: response, StripPaths: paths}).MarshalJSON()
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	minimal, defaults := marshal(DetailMinimal), marshal(DetailDefault)
+	assert.Less(t, len(minimal), len(defaults))
+	assert.JSONEq(t, `{"data":[{"extId":"vm-1","name":"web-01","powerState":"ON"}],"metadata":{"totalAvailableResults":1}}`, minimal)
+
+	// Other detail levels of v4 resource types still come from "*"
+	paths, err := StripPathsFor("vmm_vm", DetailFull)
+	require.NoError(t, err)
+	assert.Empty(t, paths)
+}
+
+func TestLoadStripProfiles(t *testing.T) {
+	defer SetStripProfiles(StripProfiles{})
+
+	file := filepath.Join(t.TempDir(), "profiles.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+"*":
+  audit: [..guest_customization]
+cluster:
+  default:
+    - entities[].status.resources.config.software_map
+    - ..api_version
+`), 0o600))
+	require.NoError(t, LoadStripProfiles(file))
+
+	paths, err := StripPathsFor("cluster", DetailDefault)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"entities[].status.resources.config.software_map", "..api_version"}, paths)
+
+	// Other resource types keep the built-in profiles and gain the configured ones
+	paths, err = StripPathsFor("image", DetailDefault)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultStripPaths, paths)
+
+	paths, err = StripPathsFor("image", "audit")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"..guest_customization"}, paths)
+
+	_, err = StripPathsFor("image", "verbose")
+	assert.ErrorContains(t, err, "available: audit, default, full, minimal")
+}
+
+func TestParseStripProfilesRejectsInvalidPaths(t *testing.T) {
+	_, err := ParseStripProfiles([]byte(`{"vm": {"default": ["spec | del(.)"]}}`))
+	assert.ErrorContains(t, err, "vm profile default")
+
+	_, err = ParseStripProfiles([]byte(`vm: [spec]`))
+	assert.Error(t, err)
+}
+
+func TestEntityStripPathsFor(t *testing.T) {
+	SetStripProfiles(StripProfiles{})
+
+	// v3 entities of reads are the response itself
+	paths, err := EntityStripPathsFor("vm", DetailMinimal)
+	require.NoError(t, err)
+	assert.Contains(t, paths, "spec")
+	assert.Contains(t, paths, "status.resources.guest_customization")
+	assert.Contains(t, paths, "entities[].spec")
+
+	// v4 entities of reads are the object under data
+	paths, err = EntityStripPathsFor("vmm_vm", DetailMinimal)
+	require.NoError(t, err)
+	assert.Contains(t, paths, "data.disks")
+	assert.Contains(t, paths, "data[].disks")
+
+	paths, err = EntityStripPathsFor("cluster", DetailFull)
+	require.NoError(t, err)
+	assert.Empty(t, paths)
+
+	_, err = EntityStripPathsFor("vm", "verbose")
+	assert.ErrorContains(t, err, "unknown detail")
+}
@@ -0,0 +1,34 @@
+package json
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateFieldPath checks that a field path such as spec.resources.disk_list[].uuid is well formed.
+// Field paths use the path grammar without recursive descent.
+func ValidateFieldPath(path string) error {
+	if path == "" {
+		return fmt.Errorf("field path must not be empty")
+	}
+
+	_, err := parsePath(path, false)
+	return err
+}
+
+// ParseFieldList splits a comma-separated list of field paths and validates each of them
+func ParseFieldList(raw string) ([]string, error) {
+	var fields []string
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if err := ValidateFieldPath(field); err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
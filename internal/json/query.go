@@ -0,0 +1,90 @@
+package json
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/itchyny/gojq"
+)
+
+// QueryLimits bound the execution of a user-supplied jq expression
+type QueryLimits struct {
+	// Timeout aborts queries running longer, e.g. with unbounded range or recursion
+	Timeout time.Duration
+	// MaxOutputBytes aborts queries whose encoded results grow larger
+	MaxOutputBytes int
+}
+
+// DefaultQueryLimits are the limits of jq expressions passed to list tools and resources
+var DefaultQueryLimits = QueryLimits{
+	Timeout:        5 * time.Second,
+	MaxOutputBytes: 1 << 20,
+}
+
+// Query is a compiled user-supplied jq expression
+type Query struct {
+	Expression string
+	code       *gojq.Code
+}
+
+// CompileQuery parses and compiles a jq expression such as
+// .entities[] | select(.status.resources.num_sockets > 4) | .spec.name
+func CompileQuery(expression string) (*Query, error) {
+	query, err := gojq.Parse(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jq expression %q: %v", expression, err)
+	}
+
+	code, err := gojq.Compile(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jq expression %q: %v", expression, err)
+	}
+
+	return &Query{Expression: expression, code: code}, nil
+}
+
+// Run runs the query over a JSON document within the limits.
+// The results are always returned as an array, with one element per value the expression outputs.
+func (q *Query) Run(ctx context.Context, data []byte, limits QueryLimits) ([]byte, error) {
+	var input interface{}
+	if err := json.Unmarshal(data, &input); err != nil {
+		return nil, fmt.Errorf("failed to decode jq input: %w", err)
+	}
+
+	if limits.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, limits.Timeout)
+		defer cancel()
+	}
+
+	results := make([]json.RawMessage, 0, 1)
+	size := 0
+	iter := q.code.RunWithContext(ctx, input)
+	for {
+		value, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if err, ok := value.(error); ok {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return nil, fmt.Errorf("jq expression timed out after %s", limits.Timeout)
+			}
+			return nil, fmt.Errorf("jq execution error: %v", err)
+		}
+
+		result, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode jq result: %w", err)
+		}
+		size += len(result) + 1
+		if limits.MaxOutputBytes > 0 && size > limits.MaxOutputBytes {
+			return nil, fmt.Errorf("jq output exceeds %d bytes, narrow the expression", limits.MaxOutputBytes)
+		}
+		results = append(results, result)
+	}
+
+	return json.Marshal(results)
+}
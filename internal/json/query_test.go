@@ -0,0 +1,59 @@
+package json
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const queryInput = `{"entities": [
+	{"spec": {"name": "web-01"}, "status": {"resources": {"num_sockets": 8}}},
+	{"spec": {"name": "web-02"}, "status": {"resources": {"num_sockets": 2}}},
+	{"spec": {"name": "db-01"}, "status": {"resources": {"num_sockets": 16}}}
+]}`
+
+func TestQueryRun(t *testing.T) {
+	tests := map[string]string{
+		`.entities[] | select(.status.resources.num_sockets > 4) | .spec.name`: `["web-01","db-01"]`,
+		`[.entities[].status.resources.num_sockets] | add`:                     `[26]`,
+		`.entities | length`:                            `[3]`,
+		`.entities[] | select(.spec.name == "missing")`: `[]`,
+	}
+
+	for expression, expected := range tests {
+		query, err := CompileQuery(expression)
+		require.NoError(t, err, expression)
+
+		out, err := query.Run(context.Background(), []byte(queryInput), DefaultQueryLimits)
+		require.NoError(t, err, expression)
+		assert.JSONEq(t, expected, string(out), expression)
+	}
+}
+
+func TestCompileQueryErrors(t *testing.T) {
+	_, err := CompileQuery(`.entities[] | select(`)
+	assert.ErrorContains(t, err, "invalid jq expression")
+
+	_, err = CompileQuery(`undefined_function(1)`)
+	assert.ErrorContains(t, err, "invalid jq expression")
+}
+
+func TestQueryRunLimits(t *testing.T) {
+	query, err := CompileQuery(`[range(1e9)] | length`)
+	require.NoError(t, err)
+	_, err = query.Run(context.Background(), []byte(`{}`), QueryLimits{Timeout: 50 * time.Millisecond})
+	assert.ErrorContains(t, err, "timed out")
+
+	query, err = CompileQuery(`range(1000) | "padding"`)
+	require.NoError(t, err)
+	_, err = query.Run(context.Background(), []byte(`{}`), QueryLimits{MaxOutputBytes: 1024})
+	assert.ErrorContains(t, err, "exceeds 1024 bytes")
+
+	query, err = CompileQuery(`.entities[0] | error("no access")`)
+	require.NoError(t, err)
+	_, err = query.Run(context.Background(), []byte(queryInput), DefaultQueryLimits)
+	assert.ErrorContains(t, err, "jq execution error")
+}
@@ -0,0 +1,321 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RedactedValue replaces every redacted secret
+const RedactedValue = "[REDACTED]"
+
+// RedactionRule masks a secret, either the value of any key matching Pattern,
+// or every match of Pattern in string values and text. When a value pattern has a
+// group named "secret", only that group is masked, e.g. the password of password=hunter2.
+type RedactionRule struct {
+	Name    string `json:"name" yaml:"name"`
+	Pattern string `json:"pattern" yaml:"pattern"`
+
+	re *regexp.Regexp
+}
+
+// RedactionRules holds the key name and value pattern rules of a Redactor
+type RedactionRules struct {
+	Keys   []RedactionRule `json:"keys" yaml:"keys"`
+	Values []RedactionRule `json:"values" yaml:"values"`
+}
+
+// builtinRedactionRules mask passwords, cloud-init and sysprep payloads, API keys, bearer tokens and private keys
+var builtinRedactionRules = RedactionRules{
+	Keys: []RedactionRule{
+		{Name: "password", Pattern: `(?i)passw(or)?d|passphrase|^pwd$`},
+		{Name: "secret", Pattern: `(?i)secret`},
+		{Name: "token", Pattern: `(?i)token`},
+		{Name: "api_key", Pattern: `(?i)api_?key|access_?key`},
+		{Name: "private_key", Pattern: `(?i)private_?key`},
+		{Name: "authorization", Pattern: `(?i)^(authorization|cookie)$`},
+		{Name: "cloud_init", Pattern: `(?i)^(cloud_?init|user_?data)$`},
+		{Name: "sysprep", Pattern: `(?i)^(sysprep|unattend_?xml)$`},
+	},
+	Values: []RedactionRule{
+		{Name: "private_key", Pattern: `-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`},
+		{Name: "bearer_token", Pattern: `(?i)\bbearer\s+(?P<secret>[A-Za-z0-9\-._~+/]+=*)`},
+		{Name: "authorization", Pattern: `(?i)\bauthorization:\s*basic\s+(?P<secret>[A-Za-z0-9+/]+=*)`},
+		{Name: "jwt", Pattern: `\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`},
+		{Name: "api_key", Pattern: `\b(AKIA|ASIA)[A-Z0-9]{16}\b|\bgh[pousr]_[A-Za-z0-9]{36,}\b|\bxox[abprs]-[A-Za-z0-9-]{10,}`},
+		{Name: "url_credentials", Pattern: `(?i)\b[a-z][a-z0-9+.-]*://[^/\s:@]+:(?P<secret>[^/\s@]+)@`},
+		{Name: "password", Pattern: `(?i)(password|passwd|pwd|secret|api_?key|token)\b["']?\s*[:=]\s*["']?(?P<secret>[^\s"',;]+)`},
+	},
+}
+
+// RedactionCounts counts the redactions of a response per rule name
+type RedactionCounts map[string]int
+
+// Total returns the number of redactions
+func (c RedactionCounts) Total() int {
+	total := 0
+	for _, n := range c {
+		total += n
+	}
+	return total
+}
+
+// Add adds the counts of another response
+func (c RedactionCounts) Add(other RedactionCounts) {
+	for name, n := range other {
+		c[name] += n
+	}
+}
+
+// Redactor masks secrets in JSON documents and text
+type Redactor struct {
+	rules RedactionRules
+
+	// keys caches the rule name matching each key, as responses repeat the same few keys
+	keys sync.Map
+}
+
+// NewRedactor compiles the rules into a Redactor
+func NewRedactor(rules RedactionRules) (*Redactor, error) {
+	compiled := RedactionRules{}
+	for _, set := range []struct {
+		kind string
+		in   []RedactionRule
+		out  *[]RedactionRule
+	}{
+		{"key", rules.Keys, &compiled.Keys},
+		{"value", rules.Values, &compiled.Values},
+	} {
+		for _, rule := range set.in {
+			if strings.TrimSpace(rule.Name) == "" {
+				return nil, fmt.Errorf("%s rule %q: name must not be empty", set.kind, rule.Pattern)
+			}
+			re, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("%s rule %s: %w", set.kind, rule.Name, err)
+			}
+			rule.re = re
+			*set.out = append(*set.out, rule)
+		}
+	}
+
+	return &Redactor{rules: compiled}, nil
+}
+
+var (
+	redactorMu sync.RWMutex
+	redactor   = mustRedactor(builtinRedactionRules)
+)
+
+func mustRedactor(rules RedactionRules) *Redactor {
+	r, err := NewRedactor(rules)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// LoadRedactionRules reads redaction rules from a YAML or JSON file such as
+//
+//	keys:
+//	  - name: license
+//	    pattern: (?i)license_key
+//	values:
+//	  - name: internal_token
+//	    pattern: itk_[A-Za-z0-9]{32}
+//
+// The configured rules are applied in addition to the built-in ones.
+func LoadRedactionRules(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read redaction rules: %w", err)
+	}
+
+	rules := RedactionRules{}
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return fmt.Errorf("invalid redaction rules in %s: %w", path, err)
+	}
+
+	return SetRedactionRules(rules)
+}
+
+// SetRedactionRules replaces the configured redaction rules, keeping the built-in ones
+func SetRedactionRules(rules RedactionRules) error {
+	r, err := NewRedactor(RedactionRules{
+		Keys:   append(append([]RedactionRule{}, builtinRedactionRules.Keys...), rules.Keys...),
+		Values: append(append([]RedactionRule{}, builtinRedactionRules.Values...), rules.Values...),
+	})
+	if err != nil {
+		return err
+	}
+
+	redactorMu.Lock()
+	defer redactorMu.Unlock()
+
+	redactor = r
+	return nil
+}
+
+func currentRedactor() *Redactor {
+	redactorMu.RLock()
+	defer redactorMu.RUnlock()
+
+	return redactor
+}
+
+// RedactJSON masks secrets in a JSON document with the configured rules
+func RedactJSON(data []byte) ([]byte, RedactionCounts, error) {
+	return currentRedactor().RedactJSON(data)
+}
+
+// Redact masks secrets in a decoded JSON value with the configured rules
+func Redact(value interface{}) (interface{}, RedactionCounts) {
+	return currentRedactor().Redact(value)
+}
+
+// RedactText masks secrets in text, such as command output or logs, with the configured rules
+func RedactText(text string) (string, RedactionCounts) {
+	return currentRedactor().RedactText(text)
+}
+
+// RedactJSON masks the values of secret keys and secrets found in string values.
+// The document is returned unchanged when nothing is redacted.
+func (r *Redactor) RedactJSON(data []byte) ([]byte, RedactionCounts, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode response for redaction: %w", err)
+	}
+
+	value, counts := r.Redact(value)
+	if counts.Total() == 0 {
+		return data, counts, nil
+	}
+
+	out, err := json.Marshal(value)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode redacted response: %w", err)
+	}
+
+	return out, counts, nil
+}
+
+// Redact masks the values of secret keys and secrets found in string values of a decoded JSON value.
+// Maps and slices are redacted in place.
+func (r *Redactor) Redact(value interface{}) (interface{}, RedactionCounts) {
+	counts := RedactionCounts{}
+	return r.redactValue(value, counts), counts
+}
+
+// RedactText masks every match of the value rules in text
+func (r *Redactor) RedactText(text string) (string, RedactionCounts) {
+	counts := RedactionCounts{}
+	return r.redactString(text, counts), counts
+}
+
+func (r *Redactor) redactValue(value interface{}, counts RedactionCounts) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if name := r.secretKey(key); name != "" && isSecretValue(item) {
+				v[key] = RedactedValue
+				counts[name]++
+				continue
+			}
+			v[key] = r.redactValue(item, counts)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = r.redactValue(item, counts)
+		}
+	case string:
+		return r.redactString(v, counts)
+	}
+
+	return value
+}
+
+// secretKey returns the name of the first key rule matching a key
+func (r *Redactor) secretKey(key string) string {
+	if name, ok := r.keys.Load(key); ok {
+		return name.(string)
+	}
+
+	name := ""
+	for _, rule := range r.rules.Keys {
+		if rule.re.MatchString(key) {
+			name = rule.Name
+			break
+		}
+	}
+	r.keys.Store(key, name)
+	return name
+}
+
+// isSecretValue reports whether a value can hold a secret; flags, numbers and empty values are kept
+func isSecretValue(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return v != "" && v != RedactedValue
+	case map[string]interface{}:
+		return len(v) > 0
+	case []interface{}:
+		return len(v) > 0
+	default:
+		return false
+	}
+}
+
+func (r *Redactor) redactString(s string, counts RedactionCounts) string {
+	for _, rule := range r.rules.Values {
+		secret := rule.re.SubexpIndex("secret")
+
+		matches := rule.re.FindAllStringSubmatchIndex(s, -1)
+		if len(matches) == 0 {
+			continue
+		}
+
+		var out strings.Builder
+		last := 0
+		for _, match := range matches {
+			start, end := match[0], match[1]
+			if secret > 0 {
+				start, end = match[2*secret], match[2*secret+1]
+			}
+			// Skip empty groups and values redacted before, so that redacting twice counts once
+			if start < 0 || start == end || s[start:end] == RedactedValue {
+				continue
+			}
+			out.WriteString(s[last:start])
+			out.WriteString(RedactedValue)
+			last = end
+			counts[rule.Name]++
+		}
+		out.WriteString(s[last:])
+		s = out.String()
+	}
+
+	return s
+}
+
+// RedactionAudit reports the redactions of a response
+type RedactionAudit struct {
+	Redacted int             `json:"redacted"`
+	Rules    RedactionCounts `json:"rules"`
+}
+
+// NewRedactionAudit returns the audit of the counts, or nil if nothing was redacted
+func NewRedactionAudit(counts RedactionCounts) *RedactionAudit {
+	if counts.Total() == 0 {
+		return nil
+	}
+
+	return &RedactionAudit{Redacted: counts.Total(), Rules: counts}
+}
@@ -0,0 +1,410 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// entityList is a list response split into its entity array and the fields around it,
+// so that the entities can be shaped and encoded one at a time
+type entityList struct {
+	// Key is the field holding the entities, e.g. entities or data
+	Key string
+	// Envelope is the decoded response without the entities
+	Envelope map[string]interface{}
+	// Len is the number of entities
+	Len int
+	// Entity returns an entity, still to be encoded
+	Entity func(i int) interface{}
+}
+
+var marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// splitEntityList splits a list response, a struct or map with a non-empty entities or data array,
+// and reports whether it could be split
+func splitEntityList(value interface{}) (*entityList, bool, error) {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, false, nil
+		}
+		v = v.Elem()
+	}
+
+	var (
+		list     *entityList
+		envelope interface{}
+	)
+	switch v.Kind() {
+	case reflect.Struct:
+		// Types encoding themselves, such as v4 responses, cannot be split field by field
+		if v.Type().Implements(marshalerType) || reflect.PtrTo(v.Type()).Implements(marshalerType) {
+			return nil, false, nil
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			key := strings.Split(field.Tag.Get("json"), ",")[0]
+			entities := v.Field(i)
+			if !field.IsExported() || !isEntityArrayKey(key) || entities.Kind() != reflect.Slice || entities.Len() == 0 {
+				continue
+			}
+
+			// Encode a copy of the response without the entities
+			rest := reflect.New(v.Type())
+			rest.Elem().Set(v)
+			rest.Elem().Field(i).Set(reflect.Zero(field.Type))
+			envelope = rest.Interface()
+			list = &entityList{Key: key, Len: entities.Len(), Entity: func(i int) interface{} { return entities.Index(i).Interface() }}
+			break
+		}
+	case reflect.Map:
+		object, ok := v.Interface().(map[string]interface{})
+		if !ok {
+			return nil, false, nil
+		}
+		for _, key := range entityArrayKeys {
+			entities, ok := object[key].([]interface{})
+			if !ok || len(entities) == 0 {
+				continue
+			}
+
+			rest := make(map[string]interface{}, len(object)-1)
+			for k, item := range object {
+				if k != key {
+					rest[k] = item
+				}
+			}
+			envelope = rest
+			list = &entityList{Key: key, Len: len(entities), Entity: func(i int) interface{} { return entities[i] }}
+			break
+		}
+	}
+	if list == nil {
+		return nil, false, nil
+	}
+
+	decoded, err := decodeValue(envelope)
+	if err != nil {
+		return nil, false, err
+	}
+	object, ok := decoded.(map[string]interface{})
+	if !ok {
+		return nil, false, nil
+	}
+	delete(object, list.Key)
+	list.Envelope = object
+
+	return list, true, nil
+}
+
+func isEntityArrayKey(key string) bool {
+	for _, k := range entityArrayKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeValue encodes a value and decodes it into maps, slices and json.Number values
+func decodeValue(value interface{}) (interface{}, error) {
+	return newCodec().decode(value)
+}
+
+// codec decodes and encodes the entities of a list one after the other, reusing its buffers
+type codec struct {
+	pipe    bytes.Buffer
+	encoder *json.Encoder
+	decoder *json.Decoder
+}
+
+func newCodec() *codec {
+	c := &codec{}
+	c.encoder = json.NewEncoder(&c.pipe)
+	c.decoder = json.NewDecoder(&c.pipe)
+	c.decoder.UseNumber()
+	return c
+}
+
+// decode encodes a value and decodes it into maps, slices and json.Number values
+func (c *codec) decode(value interface{}) (interface{}, error) {
+	if err := c.encoder.Encode(value); err != nil {
+		return nil, err
+	}
+
+	var decoded interface{}
+	if err := c.decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+
+	return decoded, nil
+}
+
+// entityMatch tells what a filter does with the entity array as a whole
+type entityMatch int
+
+const (
+	// matchNone leaves the array to the entity paths
+	matchNone entityMatch = iota
+	// matchArray matches the array itself, e.g. entities
+	matchArray
+	// matchElements matches every entity, e.g. entities[]
+	matchElements
+)
+
+// splitFilter splits a filter into one applying to the envelope of a list response and one applying to each
+// entity of the array at key. It reports false when a path cannot be split, e.g. one starting with *.
+func splitFilter(filter *PathFilter, key string) (envelope *PathFilter, entity *PathFilter, match entityMatch, ok bool) {
+	envelope = &PathFilter{Mode: filter.Mode}
+	entity = &PathFilter{Mode: filter.Mode}
+
+	for _, steps := range filter.steps {
+		switch first := steps[0]; {
+		case first.Kind == stepRecurse:
+			// Recursive descent matches the envelope as well as every entity
+			envelope.steps = append(envelope.steps, steps)
+			entity.steps = append(entity.steps, steps)
+		case first.Kind == stepIterate:
+			return nil, nil, matchNone, false
+		case first.Key != key:
+			envelope.steps = append(envelope.steps, steps)
+		case len(steps) == 1:
+			match = matchArray
+		case steps[1].Kind == stepRecurse:
+			entity.steps = append(entity.steps, steps[1:])
+		case steps[1].Kind != stepIterate:
+			// A key of the array never matches
+		case len(steps) == 2:
+			if match == matchNone {
+				match = matchElements
+			}
+		default:
+			entity.steps = append(entity.steps, steps[2:])
+		}
+	}
+
+	return envelope, entity, match, true
+}
+
+// Encode writes the shaped document: StripPaths are deleted, secrets are redacted and, when
+// set, only Fields are kept. The entities of list responses are encoded and shaped one at a time,
+// so that the whole response is never held as a decoded tree.
+func (d *CustomJSON) Encode(w io.Writer) error {
+	d.Redactions = RedactionCounts{}
+
+	strip, err := cachedPathFilter(FilterDelete, d.StripPaths)
+	if err != nil {
+		return err
+	}
+	keep, err := cachedPathFilter(FilterKeep, d.Fields)
+	if err != nil {
+		return err
+	}
+
+	list, ok, err := splitEntityList(d.Value)
+	if err != nil {
+		return err
+	}
+	if ok {
+		stripEnvelope, stripEntity, stripMatch, okStrip := splitFilter(strip, list.Key)
+		keepEnvelope, keepEntity, keepMatch, okKeep := splitFilter(keep, list.Key)
+		if okStrip && okKeep {
+			return d.encodeList(w, list, stripEnvelope, stripEntity, stripMatch, keep, keepEnvelope, keepEntity, keepMatch)
+		}
+	}
+
+	// Shape the document as a whole
+	value, err := decodeValue(d.Value)
+	if err != nil {
+		return err
+	}
+	key, uris := d.entityURIs(value)
+	value, err = d.shape(value, strip, keep)
+	if err != nil {
+		return err
+	}
+	addEntityURIs(value, key, uris)
+
+	buf := &bytes.Buffer{}
+	if err := writeJSON(json.NewEncoder(buf), buf, value); err != nil {
+		return err
+	}
+	_, err = buf.WriteTo(w)
+	return err
+}
+
+// shape strips, redacts and projects a decoded value
+func (d *CustomJSON) shape(value interface{}, strip *PathFilter, keep *PathFilter) (interface{}, error) {
+	value, err := strip.Apply(value)
+	if err != nil {
+		return nil, err
+	}
+
+	value, redactions := Redact(value)
+	d.Redactions.Add(redactions)
+
+	return keep.Apply(value)
+}
+
+func (d *CustomJSON) encodeList(w io.Writer, list *entityList,
+	stripEnvelope *PathFilter, stripEntity *PathFilter, stripMatch entityMatch,
+	keep *PathFilter, keepEnvelope *PathFilter, keepEntity *PathFilter, keepMatch entityMatch) error {
+	envelope, err := stripEnvelope.Apply(list.Envelope)
+	if err != nil {
+		return err
+	}
+	envelope, redactions := Redact(envelope)
+	d.Redactions.Add(redactions)
+
+	// Without fields, everything left after stripping is kept
+	writeEntities := stripMatch != matchArray
+	if !keep.Empty() {
+		var kept interface{} = map[string]interface{}{}
+		if !keepEnvelope.Empty() {
+			if kept, err = keepEnvelope.Apply(envelope); err != nil {
+				return err
+			}
+		}
+		envelope = kept
+		writeEntities = writeEntities && (keepMatch != matchNone || !keepEntity.Empty())
+		if keepMatch != matchNone {
+			keepEntity = &PathFilter{Mode: FilterKeep}
+		}
+	}
+
+	object, ok := envelope.(map[string]interface{})
+	if !ok {
+		object = map[string]interface{}{}
+	}
+	keys := make([]string, 0, len(object)+1)
+	for key := range object {
+		keys = append(keys, key)
+	}
+	if writeEntities {
+		keys = append(keys, list.Key)
+	}
+	sort.Strings(keys)
+
+	// Write the fields in sorted order, as encoding/json does for maps
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	entities := newCodec()
+	buf.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := writeJSON(encoder, buf, key); err != nil {
+			return err
+		}
+		buf.WriteByte(':')
+
+		if key != list.Key || !writeEntities {
+			if err := writeJSON(encoder, buf, object[key]); err != nil {
+				return err
+			}
+			continue
+		}
+
+		buf.WriteByte('[')
+		if stripMatch != matchElements {
+			for j := 0; j < list.Len; j++ {
+				if j > 0 {
+					buf.WriteByte(',')
+				}
+				entity, err := entities.decode(list.Entity(j))
+				if err != nil {
+					return err
+				}
+				uri := d.entityURI(entity)
+				if entity, err = d.shape(entity, stripEntity, keepEntity); err != nil {
+					return err
+				}
+				if object, ok := entity.(map[string]interface{}); ok && uri != "" {
+					object[EntityURIKey] = uri
+				}
+				if err := writeJSON(encoder, buf, entity); err != nil {
+					return err
+				}
+
+				// Hand over completed entities so that the buffer stays small
+				if buf.Len() >= flushSize {
+					if _, err := buf.WriteTo(w); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		buf.WriteByte(']')
+	}
+	buf.WriteByte('}')
+
+	_, err = buf.WriteTo(w)
+	return err
+}
+
+// entityURI returns the resource URI of a decoded entity, or an empty string without EntityURI
+func (d *CustomJSON) entityURI(entity interface{}) string {
+	object, ok := entity.(map[string]interface{})
+	if d.EntityURI == nil || !ok {
+		return ""
+	}
+	return d.EntityURI(object)
+}
+
+// entityURIs returns the key of the entity array of a decoded list response and the resource URIs of its entities
+func (d *CustomJSON) entityURIs(value interface{}) (string, []string) {
+	object, ok := value.(map[string]interface{})
+	if d.EntityURI == nil || !ok {
+		return "", nil
+	}
+
+	for _, key := range entityArrayKeys {
+		entities, ok := object[key].([]interface{})
+		if !ok {
+			continue
+		}
+		uris := make([]string, len(entities))
+		for i, entity := range entities {
+			uris[i] = d.entityURI(entity)
+		}
+		return key, uris
+	}
+
+	return "", nil
+}
+
+// addEntityURIs adds the resource URIs to the entities of a shaped list response, unless shaping removed entities
+func addEntityURIs(value interface{}, key string, uris []string) {
+	object, ok := value.(map[string]interface{})
+	if !ok || key == "" {
+		return
+	}
+	entities, ok := object[key].([]interface{})
+	if !ok || len(entities) != len(uris) {
+		return
+	}
+
+	for i, entity := range entities {
+		if entity, ok := entity.(map[string]interface{}); ok && uris[i] != "" {
+			entity[EntityURIKey] = uris[i]
+		}
+	}
+}
+
+// flushSize is the size from which encoded entities are written out
+const flushSize = 64 << 10
+
+// writeJSON appends the encoding of a value with an encoder writing to buf, like json.Marshal
+func writeJSON(encoder *json.Encoder, buf *bytes.Buffer, value interface{}) error {
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+	// Drop the newline ending every encoded value
+	buf.Truncate(buf.Len() - 1)
+	return nil
+}
@@ -5,7 +5,9 @@ import (
 	"os"
 
 	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+	"github.com/thunderboltsid/mcp-nutanix/internal/json"
 	"github.com/thunderboltsid/mcp-nutanix/pkg/prompts"
+	"github.com/thunderboltsid/mcp-nutanix/pkg/registry"
 	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
 	"github.com/thunderboltsid/mcp-nutanix/pkg/tools"
 
@@ -13,30 +15,14 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 )
 
-// ToolRegistration holds a tool function and its handler
-type ToolRegistration struct {
-	Func    func() mcp.Tool
-	Handler server.ToolHandlerFunc
-}
-
-// ResourceRegistration represents a resource and its associated tools
-type ResourceRegistration struct {
-	Tools           []ToolRegistration
-	ResourceFunc    func() mcp.ResourceTemplate
-	ResourceHandler server.ResourceTemplateHandlerFunc
-}
-
 // initializeFromEnvIfAvailable initializes the Prism client only if environment variables are available
 func initializeFromEnvIfAvailable() {
-	endpoint := os.Getenv("NUTANIX_ENDPOINT")
-	username := os.Getenv("NUTANIX_USERNAME")
-	password := os.Getenv("NUTANIX_PASSWORD")
-
-	// Only initialize if all required environment variables are set
-	// This allows prompt-based initialization to work when env vars are not present
-	if endpoint != "" && username != "" && password != "" {
+	// Only initialize if the endpoint is set and its credentials are found in the environment variables,
+	// the *_FILE environment variables, the netrc file or the credential helper.
+	// This allows prompt-based initialization to work when they are not present
+	if client.EnvProvider.Configured() {
 		client.Init(client.PrismClientProvider)
-		fmt.Printf("Initialized Prism client from environment variables for endpoint: %s\n", endpoint)
+		fmt.Printf("Initialized Prism client from environment variables for endpoint: %s\n", os.Getenv("NUTANIX_ENDPOINT"))
 	}
 }
 
@@ -44,6 +30,30 @@ func main() {
 	// Initialize the Prism client only if environment variables are available
 	initializeFromEnvIfAvailable()
 
+	// Load the named Prism Central connection profiles selected by the profile argument of tools
+	if path := os.Getenv("MCP_PROFILES"); path != "" {
+		if err := client.LoadProfiles(path); err != nil {
+			fmt.Printf("Failed to load connection profiles: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Load the strip profiles selected by the detail argument of list tools
+	if path := os.Getenv("MCP_STRIP_PROFILES"); path != "" {
+		if err := json.LoadStripProfiles(path); err != nil {
+			fmt.Printf("Failed to load strip profiles: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Load the redaction rules masking secrets in addition to the built-in ones
+	if path := os.Getenv("MCP_REDACTION_RULES"); path != "" {
+		if err := json.LoadRedactionRules(path); err != nil {
+			fmt.Printf("Failed to load redaction rules: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	// Define server hooks for logging and debugging
 	hooks := &server.Hooks{}
 	hooks.AddOnError(func(id any, method mcp.MCPMethod, message any, err error) {
@@ -86,45 +96,43 @@ func main() {
 	// Add the prompts
 	s.AddPrompt(prompts.SetCredentials(), prompts.SetCredentialsResponse())
 
+	// Select the registrations to expose, e.g. MCP_INCLUDE=vm,cluster or MCP_EXCLUDE=ssh_exec
+	selector := registry.NewSelector(os.Getenv("MCP_INCLUDE"), os.Getenv("MCP_EXCLUDE"))
+
 	// Add standalone tools
-	s.AddTool(tools.ApiNamespacesList(), tools.ApiNamespacesListHandler())
-	s.AddTool(tools.CriticalLogs(), tools.CriticalLogsHandler())
-	s.AddTool(tools.CrashLogsCritical(), tools.CrashLogsCriticalHandler())
-	s.AddTool(tools.FetchService(), tools.FetchServiceHandler())
-	s.AddTool(tools.KernelLogsCritical(), tools.KernelLogsCriticalHandler())
-	s.AddTool(tools.SSHExec(), tools.SSHExecHandler())
-	s.AddTool(tools.SSHExecBatch(), tools.SSHExecBatchHandler())
-
-	// Define all resources and tools
-	resourceRegistrations := map[string]ResourceRegistration{
-		"vm": {
-			Tools: []ToolRegistration{
-				{
-					Func:    tools.VMList,
-					Handler: tools.VMListHandler(),
-				},
-				{
-					Func:    tools.VMCount,
-					Handler: tools.VMCountHandler(),
-				},
-			},
-			ResourceFunc:    resources.VM,
-			ResourceHandler: resources.VMHandler(),
-		},
+	for _, registration := range registry.Tools() {
+		tool := registration.Func()
+		if !selector.Enabled(tool.Name) {
+			continue
+		}
+		s.AddTool(tool, tools.WithErrorResults(registration.Handler))
 	}
 
 	// Register all tools and resources
-	for name, registration := range resourceRegistrations {
-		// Add all tools
-		for _, tool := range registration.Tools {
-			s.AddTool(tool.Func(), tool.Handler)
+	for _, registration := range registry.Resources() {
+		// Add all enabled tools
+		for _, toolRegistration := range registration.Tools {
+			tool := toolRegistration.Func()
+			if !selector.Enabled(registration.Name, tool.Name) {
+				continue
+			}
+			s.AddTool(tool, tools.WithErrorResults(toolRegistration.Handler))
 			if debugMode {
-				fmt.Printf("Registered %s resource and tool\n", name)
+				fmt.Printf("Registered %s tool for %s resource\n", tool.Name, registration.Name)
 			}
 		}
 
 		// Add the resource
-		s.AddResourceTemplate(registration.ResourceFunc(), registration.ResourceHandler)
+		if selector.Enabled(registration.Name) {
+			s.AddResourceTemplate(registration.ResourceFunc(), resources.WithErrorHints(registration.ResourceHandler))
+		}
+	}
+
+	// Add the sub-resources, e.g. vm://{uuid}/disks
+	for _, registration := range registry.SubResources() {
+		if selector.Enabled(registration.Name) {
+			s.AddResourceTemplate(registration.ResourceFunc(), resources.WithErrorHints(registration.ResourceHandler))
+		}
 	}
 
 	// Start the server
@@ -2,6 +2,7 @@ package prompts
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/thunderboltsid/mcp-nutanix/internal/client"
@@ -18,16 +19,26 @@ func SetCredentials() mcp.Prompt {
 			mcp.ArgumentDescription("Prism Central endpoint"),
 		),
 		mcp.WithArgument("username",
-			mcp.RequiredArgument(),
 			mcp.ArgumentDescription("Username of the Prism Central user"),
 		),
 		mcp.WithArgument("password",
-			mcp.RequiredArgument(),
 			mcp.ArgumentDescription("Password of the Prism Central user"),
 		),
+		mcp.WithArgument("api_key",
+			mcp.ArgumentDescription("API key of a Prism Central service account, instead of the username and password"),
+		),
 		mcp.WithArgument("insecure",
 			mcp.ArgumentDescription("Skip TLS verification (true/false)"),
 		),
+		mcp.WithArgument("ca_bundle",
+			mcp.ArgumentDescription("Path of a PEM file of CA certificates to trust, e.g. of an internal CA"),
+		),
+		mcp.WithArgument("cert_fingerprint",
+			mcp.ArgumentDescription("SHA-256 fingerprint the Prism Central certificate has to match"),
+		),
+		mcp.WithArgument("profile",
+			mcp.ArgumentDescription("Name of the connection profile to add or replace (default: "+client.DefaultProfile+")"),
+		),
 	)
 }
 
@@ -36,24 +47,51 @@ func SetCredentialsResponse() server.PromptHandlerFunc {
 		endpoint := request.Params.Arguments["endpoint"]
 		username := request.Params.Arguments["username"]
 		password := request.Params.Arguments["password"]
+		apiKey := request.Params.Arguments["api_key"]
 		insecure := request.Params.Arguments["insecure"]
+		caBundle := request.Params.Arguments["ca_bundle"]
+		fingerprint := request.Params.Arguments["cert_fingerprint"]
+		profile := request.Params.Arguments["profile"]
+		if profile == "" {
+			profile = client.DefaultProfile
+		}
+		if err := client.ValidateProfileName(profile); err != nil {
+			return nil, err
+		}
 
-		client.PrismClientProvider.UpdateValue("endpoint", endpoint)
-		client.PrismClientProvider.UpdateValue("username", username)
-		client.PrismClientProvider.UpdateValue("password", password)
-		client.PrismClientProvider.UpdateValue("insecure", insecure)
+		creds, err := client.APICredentials(username, password, apiKey)
+		if err != nil {
+			return nil, err
+		}
+		if fingerprint != "" {
+			if err := client.ValidateFingerprint(fingerprint); err != nil {
+				return nil, err
+			}
+		}
 
-		client.Init(client.PrismClientProvider)
+		values := map[string]string{
+			"endpoint":        endpoint,
+			"username":        creds.Username,
+			"password":        creds.Password,
+			"insecure":        insecure,
+			"caBundle":        caBundle,
+			"certFingerprint": fingerprint,
+		}
 
-		// Validate the credentials
-		pcInfo, err := client.GetPrismClient().V3().GetPrismCentral(ctx)
+		// Validate the credentials before they replace those of the profile, which stays in use if they fail
+		var clusterUUID string
+		replaced, err := client.InitCheckedProfile(profile, client.NewMCPModelContextClient(values), func(candidate *client.NutanixClient) error {
+			var err error
+			clusterUUID, err = validateCredentials(ctx, candidate)
+			return err
+		})
 		if err != nil {
 			return mcp.NewGetPromptResult(
 				"Failed to connect to Prism Central",
 				[]mcp.PromptMessage{
 					mcp.NewPromptMessage(
 						mcp.RoleAssistant,
-						mcp.NewTextContent(fmt.Sprintf("Failed to connect to Prism Central: %s. Please check your credentials and try again.", err.Error())),
+						mcp.NewTextContent(fmt.Sprintf("Failed to connect to Prism Central: %s. %s", err.Error(), remediation(err))),
 					),
 				},
 			), nil
@@ -64,9 +102,44 @@ func SetCredentialsResponse() server.PromptHandlerFunc {
 			[]mcp.PromptMessage{
 				mcp.NewPromptMessage(
 					mcp.RoleAssistant,
-					mcp.NewTextContent(fmt.Sprintf("Successfully connected to Prism Central %s at %s. You can now use the tools to interact with your Nutanix environment.", pcInfo.Resources.ClusterUUID, endpoint)),
+					mcp.NewTextContent(fmt.Sprintf("Successfully connected to Prism Central %s at %s as profile %s%s. You can now use the tools to interact with your Nutanix environment.", clusterUUID, endpoint, profile, replacedNote(replaced))),
 				),
 			},
 		), nil
 	}
 }
+
+// validateCredentials reads the Prism Central info with the client of a profile and returns its cluster UUID
+func validateCredentials(ctx context.Context, prismClient *client.NutanixClient) (string, error) {
+	v3Client, err := prismClient.V3()
+	if err != nil {
+		return "", err
+	}
+
+	pcInfo, err := v3Client.GetPrismCentral(ctx)
+	if err != nil {
+		return "", client.Classify(prismClient.Profile(), err)
+	}
+	if pcInfo.Resources == nil {
+		return "", nil
+	}
+
+	return pcInfo.Resources.ClusterUUID, nil
+}
+
+// remediation returns the hint of a typed client error, or asks to check the credentials
+func remediation(err error) string {
+	var clientErr *client.Error
+	if errors.As(err, &clientErr) {
+		return clientErr.Hint()
+	}
+	return "Please check your credentials and try again."
+}
+
+// replacedNote tells that the credentials replaced those of an existing profile
+func replacedNote(replaced bool) string {
+	if !replaced {
+		return ""
+	}
+	return ", replacing its previous credentials"
+}
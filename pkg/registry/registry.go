@@ -0,0 +1,107 @@
+package registry
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+	"github.com/thunderboltsid/mcp-nutanix/pkg/tools"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ToolRegistration holds a tool function and its handler
+type ToolRegistration struct {
+	Func    func() mcp.Tool
+	Handler server.ToolHandlerFunc
+}
+
+// ResourceRegistration represents a resource and its associated tools
+type ResourceRegistration struct {
+	Name            string
+	Tools           []ToolRegistration
+	ResourceFunc    func() mcp.ResourceTemplate
+	ResourceHandler server.ResourceTemplateHandlerFunc
+}
+
+// Tools returns the registrations of all standalone tools
+func Tools() []ToolRegistration {
+	return []ToolRegistration{
+		{Func: tools.ApiNamespacesList, Handler: tools.ApiNamespacesListHandler()},
+		{Func: tools.PrismAPIGet, Handler: tools.PrismAPIGetHandler()},
+		{Func: tools.VMGraph, Handler: tools.VMGraphHandler()},
+		{Func: tools.CategoryQuery, Handler: tools.CategoryQueryHandler()},
+		{Func: tools.EntityDiff, Handler: tools.EntityDiffHandler()},
+		{Func: tools.ResponseContinue, Handler: tools.ResponseContinueHandler()},
+		{Func: tools.ProfilesList, Handler: tools.ProfilesListHandler()},
+		{Func: tools.CriticalLogs, Handler: tools.CriticalLogsHandler()},
+		{Func: tools.CrashLogsCritical, Handler: tools.CrashLogsCriticalHandler()},
+		{Func: tools.FetchService, Handler: tools.FetchServiceHandler()},
+		{Func: tools.KernelLogsCritical, Handler: tools.KernelLogsCriticalHandler()},
+		{Func: tools.SSHExec, Handler: tools.SSHExecHandler()},
+		{Func: tools.SSHExecBatch, Handler: tools.SSHExecBatchHandler()},
+	}
+}
+
+// SubResources returns the registrations of the sub-resource templates, e.g. vm://{uuid}/disks.
+// They are named after their parent resource so that selecting a resource also selects its sub-resources.
+func SubResources() []ResourceRegistration {
+	return []ResourceRegistration{
+		{Name: string(resources.ResourceTypeVM), ResourceFunc: resources.VMDisks, ResourceHandler: resources.VMDisksHandler()},
+		{Name: string(resources.ResourceTypeVM), ResourceFunc: resources.VMNics, ResourceHandler: resources.VMNicsHandler()},
+		{Name: string(resources.ResourceTypeVM), ResourceFunc: resources.VMGraph, ResourceHandler: resources.VMGraphHandler()},
+		{Name: string(resources.ResourceTypeCluster), ResourceFunc: resources.ClusterVMs, ResourceHandler: resources.ClusterVMsHandler()},
+		{Name: string(resources.ResourceTypeSubnet), ResourceFunc: resources.SubnetIPs, ResourceHandler: resources.SubnetIPsHandler()},
+	}
+}
+
+// Selector decides which registrations are enabled based on include and exclude name lists.
+// Names may refer to a resource (e.g. vm), which covers its template and tools,
+// or to a single tool (e.g. vm_count).
+type Selector struct {
+	include map[string]struct{}
+	exclude map[string]struct{}
+}
+
+// NewSelector creates a selector from comma or whitespace separated include and exclude lists.
+// An empty include list enables everything that is not excluded.
+func NewSelector(include, exclude string) *Selector {
+	return &Selector{
+		include: parseNames(include),
+		exclude: parseNames(exclude),
+	}
+}
+
+// Enabled reports whether a registration known by any of the given names is enabled
+func (s *Selector) Enabled(names ...string) bool {
+	for _, name := range names {
+		if _, ok := s.exclude[name]; ok {
+			return false
+		}
+	}
+
+	if len(s.include) == 0 {
+		return true
+	}
+	for _, name := range names {
+		if _, ok := s.include[name]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseNames(raw string) map[string]struct{} {
+	fields := strings.FieldsFunc(raw, func(r rune) bool {
+		return unicode.IsSpace(r) || r == ','
+	})
+
+	names := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		names[field] = struct{}{}
+	}
+
+	return names
+}
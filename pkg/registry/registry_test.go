@@ -0,0 +1,25 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelector(t *testing.T) {
+	all := NewSelector("", "")
+	assert.True(t, all.Enabled("vm", "vm_list"))
+	assert.True(t, all.Enabled("ssh_exec"))
+
+	included := NewSelector("vm, cluster_count", "vm_count")
+	assert.True(t, included.Enabled("vm"))
+	assert.True(t, included.Enabled("vm", "vm_list"))
+	assert.False(t, included.Enabled("vm", "vm_count"))
+	assert.True(t, included.Enabled("cluster", "cluster_count"))
+	assert.False(t, included.Enabled("cluster", "cluster_list"))
+	assert.False(t, included.Enabled("cluster"))
+
+	excluded := NewSelector("", "ssh_exec ssh_exec_batch")
+	assert.False(t, excluded.Enabled("ssh_exec"))
+	assert.True(t, excluded.Enabled("critical_logs"))
+}
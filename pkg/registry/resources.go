@@ -0,0 +1,312 @@
+package registry
+
+import (
+	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+	"github.com/thunderboltsid/mcp-nutanix/pkg/tools"
+)
+
+// Resources returns the registrations of all generated resources and their tools
+func Resources() []ResourceRegistration {
+	return []ResourceRegistration{
+		{
+			Name: string(resources.ResourceTypeVM),
+			Tools: []ToolRegistration{
+				{
+					Func:    tools.VMList,
+					Handler: tools.VMListHandler(),
+				},
+				{
+					Func:    tools.VMCount,
+					Handler: tools.VMCountHandler(),
+				},
+			},
+			ResourceFunc:    resources.VM,
+			ResourceHandler: resources.VMHandler(),
+		},
+		{
+			Name: string(resources.ResourceTypeCluster),
+			Tools: []ToolRegistration{
+				{
+					Func:    tools.ClusterList,
+					Handler: tools.ClusterListHandler(),
+				},
+				{
+					Func:    tools.ClusterCount,
+					Handler: tools.ClusterCountHandler(),
+				},
+			},
+			ResourceFunc:    resources.Cluster,
+			ResourceHandler: resources.ClusterHandler(),
+		},
+		{
+			Name: string(resources.ResourceTypeHost),
+			Tools: []ToolRegistration{
+				{
+					Func:    tools.HostList,
+					Handler: tools.HostListHandler(),
+				},
+				{
+					Func:    tools.HostCount,
+					Handler: tools.HostCountHandler(),
+				},
+			},
+			ResourceFunc:    resources.Host,
+			ResourceHandler: resources.HostHandler(),
+		},
+		{
+			Name: string(resources.ResourceTypeImage),
+			Tools: []ToolRegistration{
+				{
+					Func:    tools.ImageList,
+					Handler: tools.ImageListHandler(),
+				},
+				{
+					Func:    tools.ImageCount,
+					Handler: tools.ImageCountHandler(),
+				},
+			},
+			ResourceFunc:    resources.Image,
+			ResourceHandler: resources.ImageHandler(),
+		},
+		{
+			Name: string(resources.ResourceTypeSubnet),
+			Tools: []ToolRegistration{
+				{
+					Func:    tools.SubnetList,
+					Handler: tools.SubnetListHandler(),
+				},
+				{
+					Func:    tools.SubnetCount,
+					Handler: tools.SubnetCountHandler(),
+				},
+			},
+			ResourceFunc:    resources.Subnet,
+			ResourceHandler: resources.SubnetHandler(),
+		},
+		{
+			Name: string(resources.ResourceTypeProject),
+			Tools: []ToolRegistration{
+				{
+					Func:    tools.ProjectList,
+					Handler: tools.ProjectListHandler(),
+				},
+				{
+					Func:    tools.ProjectCount,
+					Handler: tools.ProjectCountHandler(),
+				},
+			},
+			ResourceFunc:    resources.Project,
+			ResourceHandler: resources.ProjectHandler(),
+		},
+		{
+			Name: string(resources.ResourceTypeCategory),
+			Tools: []ToolRegistration{
+				{
+					Func:    tools.CategoryList,
+					Handler: tools.CategoryListHandler(),
+				},
+				{
+					Func:    tools.CategoryCount,
+					Handler: tools.CategoryCountHandler(),
+				},
+			},
+			ResourceFunc:    resources.Category,
+			ResourceHandler: resources.CategoryHandler(),
+		},
+		{
+			Name: string(resources.ResourceTypeNetworkSecurityRule),
+			Tools: []ToolRegistration{
+				{
+					Func:    tools.NetworkSecurityRuleList,
+					Handler: tools.NetworkSecurityRuleListHandler(),
+				},
+				{
+					Func:    tools.NetworkSecurityRuleCount,
+					Handler: tools.NetworkSecurityRuleCountHandler(),
+				},
+			},
+			ResourceFunc:    resources.NetworkSecurityRule,
+			ResourceHandler: resources.NetworkSecurityRuleHandler(),
+		},
+		{
+			Name: string(resources.ResourceTypeVolumeGroup),
+			Tools: []ToolRegistration{
+				{
+					Func:    tools.VolumeGroupList,
+					Handler: tools.VolumeGroupListHandler(),
+				},
+				{
+					Func:    tools.VolumeGroupCount,
+					Handler: tools.VolumeGroupCountHandler(),
+				},
+			},
+			ResourceFunc:    resources.VolumeGroup,
+			ResourceHandler: resources.VolumeGroupHandler(),
+		},
+		{
+			Name: string(resources.ResourceTypeProtectionRule),
+			Tools: []ToolRegistration{
+				{
+					Func:    tools.ProtectionRuleList,
+					Handler: tools.ProtectionRuleListHandler(),
+				},
+				{
+					Func:    tools.ProtectionRuleCount,
+					Handler: tools.ProtectionRuleCountHandler(),
+				},
+			},
+			ResourceFunc:    resources.ProtectionRule,
+			ResourceHandler: resources.ProtectionRuleHandler(),
+		},
+		{
+			Name: string(resources.ResourceTypeRecoveryPlan),
+			Tools: []ToolRegistration{
+				{
+					Func:    tools.RecoveryPlanList,
+					Handler: tools.RecoveryPlanListHandler(),
+				},
+				{
+					Func:    tools.RecoveryPlanCount,
+					Handler: tools.RecoveryPlanCountHandler(),
+				},
+			},
+			ResourceFunc:    resources.RecoveryPlan,
+			ResourceHandler: resources.RecoveryPlanHandler(),
+		},
+		{
+			Name: string(resources.ResourceTypeUser),
+			Tools: []ToolRegistration{
+				{
+					Func:    tools.UserList,
+					Handler: tools.UserListHandler(),
+				},
+				{
+					Func:    tools.UserCount,
+					Handler: tools.UserCountHandler(),
+				},
+			},
+			ResourceFunc:    resources.User,
+			ResourceHandler: resources.UserHandler(),
+		},
+		{
+			Name: string(resources.ResourceTypeRole),
+			Tools: []ToolRegistration{
+				{
+					Func:    tools.RoleList,
+					Handler: tools.RoleListHandler(),
+				},
+				{
+					Func:    tools.RoleCount,
+					Handler: tools.RoleCountHandler(),
+				},
+			},
+			ResourceFunc:    resources.Role,
+			ResourceHandler: resources.RoleHandler(),
+		},
+		{
+			Name: string(resources.ResourceTypeAccessControlPolicy),
+			Tools: []ToolRegistration{
+				{
+					Func:    tools.AccessControlPolicyList,
+					Handler: tools.AccessControlPolicyListHandler(),
+				},
+				{
+					Func:    tools.AccessControlPolicyCount,
+					Handler: tools.AccessControlPolicyCountHandler(),
+				},
+			},
+			ResourceFunc:    resources.AccessControlPolicy,
+			ResourceHandler: resources.AccessControlPolicyHandler(),
+		},
+		{
+			Name: string(resources.ResourceTypeVmmVM),
+			Tools: []ToolRegistration{
+				{
+					Func:    tools.VmmVMList,
+					Handler: tools.VmmVMListHandler(),
+				},
+				{
+					Func:    tools.VmmVMCount,
+					Handler: tools.VmmVMCountHandler(),
+				},
+			},
+			ResourceFunc:    resources.VmmVM,
+			ResourceHandler: resources.VmmVMHandler(),
+		},
+		{
+			Name: string(resources.ResourceTypeVmmImage),
+			Tools: []ToolRegistration{
+				{
+					Func:    tools.VmmImageList,
+					Handler: tools.VmmImageListHandler(),
+				},
+				{
+					Func:    tools.VmmImageCount,
+					Handler: tools.VmmImageCountHandler(),
+				},
+			},
+			ResourceFunc:    resources.VmmImage,
+			ResourceHandler: resources.VmmImageHandler(),
+		},
+		{
+			Name: string(resources.ResourceTypeClustermgmtCluster),
+			Tools: []ToolRegistration{
+				{
+					Func:    tools.ClustermgmtClusterList,
+					Handler: tools.ClustermgmtClusterListHandler(),
+				},
+				{
+					Func:    tools.ClustermgmtClusterCount,
+					Handler: tools.ClustermgmtClusterCountHandler(),
+				},
+			},
+			ResourceFunc:    resources.ClustermgmtCluster,
+			ResourceHandler: resources.ClustermgmtClusterHandler(),
+		},
+		{
+			Name: string(resources.ResourceTypeNetworkingSubnet),
+			Tools: []ToolRegistration{
+				{
+					Func:    tools.NetworkingSubnetList,
+					Handler: tools.NetworkingSubnetListHandler(),
+				},
+				{
+					Func:    tools.NetworkingSubnetCount,
+					Handler: tools.NetworkingSubnetCountHandler(),
+				},
+			},
+			ResourceFunc:    resources.NetworkingSubnet,
+			ResourceHandler: resources.NetworkingSubnetHandler(),
+		},
+		{
+			Name: string(resources.ResourceTypeStorageContainer),
+			Tools: []ToolRegistration{
+				{
+					Func:    tools.StorageContainerList,
+					Handler: tools.StorageContainerListHandler(),
+				},
+				{
+					Func:    tools.StorageContainerCount,
+					Handler: tools.StorageContainerCountHandler(),
+				},
+			},
+			ResourceFunc:    resources.StorageContainer,
+			ResourceHandler: resources.StorageContainerHandler(),
+		},
+		{
+			Name: string(resources.ResourceTypeVolumesVolumeGroup),
+			Tools: []ToolRegistration{
+				{
+					Func:    tools.VolumesVolumeGroupList,
+					Handler: tools.VolumesVolumeGroupListHandler(),
+				},
+				{
+					Func:    tools.VolumesVolumeGroupCount,
+					Handler: tools.VolumesVolumeGroupCountHandler(),
+				},
+			},
+			ResourceFunc:    resources.VolumesVolumeGroup,
+			ResourceHandler: resources.VolumesVolumeGroupHandler(),
+		},
+	}
+}
@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// AccessControlPolicy defines the AccessControlPolicy resource template
+func AccessControlPolicy() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		string(ResourceURIPrefix(ResourceTypeAccessControlPolicy))+"{uuid}{?fields,detail,jq,profile}",
+		string(ResourceTypeAccessControlPolicy),
+		mcp.WithTemplateDescription("Access Control Policy resource"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// AccessControlPolicyHandler implements the handler for the AccessControlPolicy resource
+func AccessControlPolicyHandler() server.ResourceTemplateHandlerFunc {
+	return CreateResourceHandler(ResourceTypeAccessControlPolicy, GetAccessControlPolicy)
+}
+
+// GetAccessControlPolicy gets a AccessControlPolicy by its UUID
+func GetAccessControlPolicy(ctx context.Context, client *client.NutanixClient, uuid string) (interface{}, error) {
+	v3Client, err := client.V3()
+	if err != nil {
+		return nil, err
+	}
+
+	return v3Client.GetAccessControlPolicy(ctx, uuid)
+}
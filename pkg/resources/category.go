@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Category defines the Category resource template
+func Category() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		string(ResourceURIPrefix(ResourceTypeCategory))+"{uuid}{?fields,detail,jq,profile}",
+		string(ResourceTypeCategory),
+		mcp.WithTemplateDescription("Category key resource, addressed by category name"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// CategoryHandler implements the handler for the Category resource
+func CategoryHandler() server.ResourceTemplateHandlerFunc {
+	return CreateResourceHandler(ResourceTypeCategory, GetCategory)
+}
+
+// GetCategory gets a Category by its UUID
+func GetCategory(ctx context.Context, client *client.NutanixClient, uuid string) (interface{}, error) {
+	v3Client, err := client.V3()
+	if err != nil {
+		return nil, err
+	}
+
+	return v3Client.GetCategoryKey(ctx, uuid)
+}
@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Cluster defines the Cluster resource template
+func Cluster() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		string(ResourceURIPrefix(ResourceTypeCluster))+"{uuid}{?fields,detail,jq,profile}",
+		string(ResourceTypeCluster),
+		mcp.WithTemplateDescription("Cluster resource"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// ClusterHandler implements the handler for the Cluster resource
+func ClusterHandler() server.ResourceTemplateHandlerFunc {
+	return CreateResourceHandler(ResourceTypeCluster, GetCluster)
+}
+
+// GetCluster gets a Cluster by its UUID
+func GetCluster(ctx context.Context, client *client.NutanixClient, uuid string) (interface{}, error) {
+	v3Client, err := client.V3()
+	if err != nil {
+		return nil, err
+	}
+
+	return v3Client.GetCluster(ctx, uuid)
+}
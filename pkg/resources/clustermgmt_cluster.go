@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ClustermgmtCluster defines the ClustermgmtCluster resource template
+func ClustermgmtCluster() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		string(ResourceURIPrefix(ResourceTypeClustermgmtCluster))+"{extId}{?fields,detail,jq,profile}",
+		string(ResourceTypeClustermgmtCluster),
+		mcp.WithTemplateDescription("Cluster resource (v4 clustermgmt API), addressed by extId"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// ClustermgmtClusterHandler implements the handler for the ClustermgmtCluster resource
+func ClustermgmtClusterHandler() server.ResourceTemplateHandlerFunc {
+	return CreateResourceHandler(ResourceTypeClustermgmtCluster, GetClustermgmtCluster)
+}
+
+// GetClustermgmtCluster gets a ClustermgmtCluster by its extId
+func GetClustermgmtCluster(ctx context.Context, client *client.NutanixClient, extID string) (interface{}, error) {
+	v4Client, err := client.V4()
+	if err != nil {
+		return nil, err
+	}
+
+	return v4Client.ClustersApiInstance.GetClusterById(&extID)
+}
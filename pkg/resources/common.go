@@ -3,7 +3,7 @@ package resources
 import (
 	"context"
 	"fmt"
-	"strings"
+	"net/url"
 
 	"github.com/thunderboltsid/mcp-nutanix/internal/client"
 	"github.com/thunderboltsid/mcp-nutanix/internal/json"
@@ -16,7 +16,28 @@ import (
 type ResourceType string
 
 const (
-	ResourceTypeVM ResourceType = "vm"
+	ResourceTypeVM                  ResourceType = "vm"
+	ResourceTypeCluster             ResourceType = "cluster"
+	ResourceTypeHost                ResourceType = "host"
+	ResourceTypeImage               ResourceType = "image"
+	ResourceTypeSubnet              ResourceType = "subnet"
+	ResourceTypeProject             ResourceType = "project"
+	ResourceTypeCategory            ResourceType = "category"
+	ResourceTypeNetworkSecurityRule ResourceType = "network_security_rule"
+	ResourceTypeVolumeGroup         ResourceType = "volume_group"
+	ResourceTypeProtectionRule      ResourceType = "protection_rule"
+	ResourceTypeRecoveryPlan        ResourceType = "recovery_plan"
+	ResourceTypeUser                ResourceType = "user"
+	ResourceTypeRole                ResourceType = "role"
+	ResourceTypeAccessControlPolicy ResourceType = "access_control_policy"
+
+	// v4 API resource types, addressed by extId
+	ResourceTypeVmmVM              ResourceType = "vmm_vm"
+	ResourceTypeVmmImage           ResourceType = "vmm_image"
+	ResourceTypeClustermgmtCluster ResourceType = "clustermgmt_cluster"
+	ResourceTypeNetworkingSubnet   ResourceType = "networking_subnet"
+	ResourceTypeStorageContainer   ResourceType = "storage_container"
+	ResourceTypeVolumesVolumeGroup ResourceType = "volumes_volume_group"
 )
 
 // ResourceHandlerFunc defines a function that handles a specific resource get operation
@@ -32,38 +53,114 @@ func NutanixURI(resourceType ResourceType, uuid string) string {
 	return fmt.Sprintf("%s://%s", resourceType, uuid)
 }
 
+// kindResourceTypes maps v3 reference kinds to the resource types addressing them
+var kindResourceTypes = map[string]ResourceType{
+	"vm":                    ResourceTypeVM,
+	"cluster":               ResourceTypeCluster,
+	"host":                  ResourceTypeHost,
+	"subnet":                ResourceTypeSubnet,
+	"image":                 ResourceTypeImage,
+	"volume_group":          ResourceTypeVolumeGroup,
+	"project":               ResourceTypeProject,
+	"user":                  ResourceTypeUser,
+	"role":                  ResourceTypeRole,
+	"network_security_rule": ResourceTypeNetworkSecurityRule,
+	"protection_rule":       ResourceTypeProtectionRule,
+	"recovery_plan":         ResourceTypeRecoveryPlan,
+	"access_control_policy": ResourceTypeAccessControlPolicy,
+}
+
+// NutanixKindURI returns a URI for a v3 reference kind and UUID,
+// or an empty string if no resource addresses the kind
+func NutanixKindURI(kind string, uuid string) string {
+	resourceType, ok := kindResourceTypes[kind]
+	if !ok {
+		return ""
+	}
+	return NutanixURI(resourceType, uuid)
+}
+
 // ExtractIDFromURI extracts the UUID from a URI
-// uri is expected to be in the format of resourceType://uuid
+// uri is expected to be in the format of resourceType://uuid[/subResource][?query]
 func ExtractIDFromURI(uri string) string {
-	parts := strings.Split(uri, "://")
-	if len(parts) != 2 {
+	parsed, err := ParseResourceURI(uri)
+	if err != nil {
 		return ""
 	}
-	return parts[1]
+	return parsed.ID
+}
+
+// ExtractQueryFromURI extracts the query parameters from a URI
+// uri is expected to be in the format of resourceType://uuid[/subResource][?query]
+func ExtractQueryFromURI(uri string) (url.Values, error) {
+	parsed, err := ParseResourceURI(uri)
+	if err != nil {
+		return nil, err
+	}
+	return parsed.Query, nil
 }
 
 // ExtractTypeFromURI extracts the resource type from a URI
-// uri is expected to be in the format of resourceType://uuid
+// uri is expected to be in the format of resourceType://uuid[/subResource][?query]
 func ExtractTypeFromURI(uri string) ResourceType {
-	parts := strings.Split(uri, "://")
-	if len(parts) != 2 {
+	parsed, err := ParseResourceURI(uri)
+	if err != nil {
 		return ""
 	}
-	return ResourceType(parts[0])
+	return parsed.Type
 }
 
 // CreateResourceHandler creates a generic resource handler for any Nutanix resource
 func CreateResourceHandler(resourceType ResourceType, handlerFunc ResourceHandlerFunc) server.ResourceTemplateHandlerFunc {
+	return CreateSubResourceHandler(resourceType, "", handlerFunc)
+}
+
+// CreateSubResourceHandler creates a generic resource handler for a sub-resource of a Nutanix resource,
+// e.g. the disks of vm://{uuid}/disks. An empty subResource handles the resource itself.
+func CreateSubResourceHandler(resourceType ResourceType, subResource string, handlerFunc ResourceHandlerFunc) server.ResourceTemplateHandlerFunc {
 	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		uuid := ExtractIDFromURI(request.Params.URI)
-		if uuid == "" {
-			return nil, fmt.Errorf("URI must contain a UUID")
+		uri, err := ParseResourceURI(request.Params.URI)
+		if err != nil {
+			return nil, err
 		}
+		if uri.Type != resourceType || uri.SubResource != subResource {
+			return nil, fmt.Errorf("URI %s does not address a %s resource", request.Params.URI, resourceType)
+		}
+		uuid := uri.ID
 
-		// Get the Prism client
-		prismClient := client.GetPrismClient()
-		if prismClient == nil {
-			return nil, fmt.Errorf("prism client not initialized, please set credentials first")
+		// Get the fields to project if provided, e.g. ?fields=spec.name,metadata.uuid
+		fields, err := json.ParseFieldList(uri.Query.Get("fields"))
+		if err != nil {
+			return nil, err
+		}
+
+		// Get the paths to strip for the detail level if provided, e.g. ?detail=minimal
+		stripPaths, err := json.EntityStripPathsFor(string(resourceType), uri.Query.Get("detail"))
+		if err != nil {
+			return nil, err
+		}
+
+		// Get the view if provided, e.g. ?view=summary
+		view, err := ParseView(resourceType, uri.Query.Get("view"))
+		if err != nil {
+			return nil, err
+		}
+		if view != ViewFull && subResource != "" {
+			return nil, fmt.Errorf("view %q is not supported for sub-resources", view)
+		}
+
+		// Compile the jq expression if provided, e.g. ?jq=.status.resources.nic_list[].ip_endpoint_list
+		var query *json.Query
+		if expression := uri.Query.Get("jq"); expression != "" {
+			if query, err = json.CompileQuery(expression); err != nil {
+				return nil, err
+			}
+		}
+
+		// Get the Prism client of the selected profile, e.g. ?profile=dr
+		prismClient, err := client.LookupProfile(uri.Query.Get("profile"))
+		if err != nil {
+			return nil, err
 		}
 
 		// Call the specific resource handler
@@ -72,19 +169,33 @@ func CreateResourceHandler(resourceType ResourceType, handlerFunc ResourceHandle
 			return nil, fmt.Errorf("failed to get %s: %w", resourceType, err)
 		}
 
+		resource, err = ApplyView(resourceType, view, resource)
+		if err != nil {
+			return nil, err
+		}
+
 		// Convert to JSON
-		cjson := json.CustomJSONEncoder(resource)
+		cjson := json.StrippedJSONEncoder(resource, stripPaths, fields)
 		jsonBytes, err := cjson.MarshalJSON()
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal %s details: %w", resourceType, err)
 		}
 
-		return []mcp.ResourceContents{
+		if query != nil {
+			jsonBytes, err = query.Run(ctx, jsonBytes, json.DefaultQueryLimits)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Report the secrets masked while encoding after the resource
+		contents := []mcp.ResourceContents{
 			&mcp.TextResourceContents{
 				URI:      request.Params.URI,
 				MIMEType: "application/json",
 				Text:     string(jsonBytes),
 			},
-		}, nil
+		}
+		return append(contents, redactionContents(request.Params.URI, cjson.Redactions)...), nil
 	}
 }
@@ -0,0 +1,52 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceDetail(t *testing.T) {
+	client.InitProfile("detail", client.NewMCPModelContextClient(map[string]string{"endpoint": "10.0.0.1"}))
+
+	read := func(resourceType ResourceType, resource interface{}, uri string) string {
+		handler := CreateResourceHandler(resourceType, func(ctx context.Context, _ *client.NutanixClient, id string) (interface{}, error) {
+			return resource, nil
+		})
+		request := mcp.ReadResourceRequest{}
+		request.Params.URI = uri
+
+		contents, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		return contents[0].(*mcp.TextResourceContents).Text
+	}
+
+	vm := map[string]interface{}{
+		"api_version": "3.1",
+		"metadata":    map[string]interface{}{"uuid": "u1"},
+		"spec":        map[string]interface{}{"name": "web-01"},
+		"status":      map[string]interface{}{"name": "web-01", "resources": map[string]interface{}{"guest_customization": map[string]interface{}{}}},
+	}
+	assert.JSONEq(t, `{"metadata":{"uuid":"u1"},"spec":{"name":"web-01"},"status":{"name":"web-01","resources":{}}}`,
+		read(ResourceTypeVM, vm, "vm://u1?profile=detail"))
+	assert.JSONEq(t, `{"metadata":{"uuid":"u1"},"status":{"name":"web-01","resources":{}}}`,
+		read(ResourceTypeVM, vm, "vm://u1?profile=detail&detail=minimal"))
+	assert.Contains(t, read(ResourceTypeVM, vm, "vm://u1?profile=detail&detail=full"), "guest_customization")
+
+	// v4 entities drop the nested configuration of the minimal profile
+	v4VM := map[string]interface{}{"data": map[string]interface{}{"extId": "e1", "name": "web-01", "disks": []interface{}{}}}
+	assert.JSONEq(t, `{"data":{"extId":"e1","name":"web-01"}}`, read(ResourceTypeVmmVM, v4VM, "vmm_vm://e1?profile=detail&detail=minimal"))
+
+	handler := CreateResourceHandler(ResourceTypeVM, func(ctx context.Context, _ *client.NutanixClient, id string) (interface{}, error) {
+		return vm, nil
+	})
+	request := mcp.ReadResourceRequest{}
+	request.Params.URI = "vm://u1?profile=detail&detail=verbose"
+	_, err := handler(context.Background(), request)
+	assert.ErrorContains(t, err, "unknown detail")
+}
@@ -0,0 +1,44 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/klog"
+)
+
+// WithErrorHints wraps a resource handler so that failures never crash the stdio server:
+// Prism client errors get a remediation hint, and panics become errors
+func WithErrorHints(handler server.ResourceTemplateHandlerFunc) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) (contents []mcp.ResourceContents, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				klog.Errorf("resource %s panicked: %v\n%s", request.Params.URI, r, debug.Stack())
+				contents, err = nil, fmt.Errorf("reading %s failed unexpectedly: %v", request.Params.URI, r)
+			}
+		}()
+
+		contents, err = handler(ctx, request)
+		if err == nil {
+			return contents, nil
+		}
+
+		var profile string
+		if uri, parseErr := ParseResourceURI(request.Params.URI); parseErr == nil {
+			profile = uri.Query.Get("profile")
+		}
+
+		var clientErr *client.Error
+		if classified := client.Classify(profile, err); errors.As(classified, &clientErr) {
+			return nil, fmt.Errorf("%w. %s", classified, clientErr.Hint())
+		}
+
+		return nil, err
+	}
+}
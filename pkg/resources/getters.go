@@ -0,0 +1,25 @@
+package resources
+
+// Getters maps every resource type to the function getting a resource by its UUID or extId
+var Getters = map[ResourceType]ResourceHandlerFunc{
+	ResourceTypeVM:                  GetVM,
+	ResourceTypeCluster:             GetCluster,
+	ResourceTypeHost:                GetHost,
+	ResourceTypeImage:               GetImage,
+	ResourceTypeSubnet:              GetSubnet,
+	ResourceTypeProject:             GetProject,
+	ResourceTypeCategory:            GetCategory,
+	ResourceTypeNetworkSecurityRule: GetNetworkSecurityRule,
+	ResourceTypeVolumeGroup:         GetVolumeGroup,
+	ResourceTypeProtectionRule:      GetProtectionRule,
+	ResourceTypeRecoveryPlan:        GetRecoveryPlan,
+	ResourceTypeUser:                GetUser,
+	ResourceTypeRole:                GetRole,
+	ResourceTypeAccessControlPolicy: GetAccessControlPolicy,
+	ResourceTypeVmmVM:               GetVmmVM,
+	ResourceTypeVmmImage:            GetVmmImage,
+	ResourceTypeClustermgmtCluster:  GetClustermgmtCluster,
+	ResourceTypeNetworkingSubnet:    GetNetworkingSubnet,
+	ResourceTypeStorageContainer:    GetStorageContainer,
+	ResourceTypeVolumesVolumeGroup:  GetVolumesVolumeGroup,
+}
@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Host defines the Host resource template
+func Host() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		string(ResourceURIPrefix(ResourceTypeHost))+"{uuid}{?fields,detail,jq,profile}",
+		string(ResourceTypeHost),
+		mcp.WithTemplateDescription("Host resource"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// HostHandler implements the handler for the Host resource
+func HostHandler() server.ResourceTemplateHandlerFunc {
+	return CreateResourceHandler(ResourceTypeHost, GetHost)
+}
+
+// GetHost gets a Host by its UUID
+func GetHost(ctx context.Context, client *client.NutanixClient, uuid string) (interface{}, error) {
+	v3Client, err := client.V3()
+	if err != nil {
+		return nil, err
+	}
+
+	return v3Client.GetHost(ctx, uuid)
+}
@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Image defines the Image resource template
+func Image() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		string(ResourceURIPrefix(ResourceTypeImage))+"{uuid}{?fields,detail,jq,profile}",
+		string(ResourceTypeImage),
+		mcp.WithTemplateDescription("Image resource"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// ImageHandler implements the handler for the Image resource
+func ImageHandler() server.ResourceTemplateHandlerFunc {
+	return CreateResourceHandler(ResourceTypeImage, GetImage)
+}
+
+// GetImage gets a Image by its UUID
+func GetImage(ctx context.Context, client *client.NutanixClient, uuid string) (interface{}, error) {
+	v3Client, err := client.V3()
+	if err != nil {
+		return nil, err
+	}
+
+	return v3Client.GetImage(ctx, uuid)
+}
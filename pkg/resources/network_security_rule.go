@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// NetworkSecurityRule defines the NetworkSecurityRule resource template
+func NetworkSecurityRule() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		string(ResourceURIPrefix(ResourceTypeNetworkSecurityRule))+"{uuid}{?fields,detail,jq,profile}",
+		string(ResourceTypeNetworkSecurityRule),
+		mcp.WithTemplateDescription("Network Security Rule resource"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// NetworkSecurityRuleHandler implements the handler for the NetworkSecurityRule resource
+func NetworkSecurityRuleHandler() server.ResourceTemplateHandlerFunc {
+	return CreateResourceHandler(ResourceTypeNetworkSecurityRule, GetNetworkSecurityRule)
+}
+
+// GetNetworkSecurityRule gets a NetworkSecurityRule by its UUID
+func GetNetworkSecurityRule(ctx context.Context, client *client.NutanixClient, uuid string) (interface{}, error) {
+	v3Client, err := client.V3()
+	if err != nil {
+		return nil, err
+	}
+
+	return v3Client.GetNetworkSecurityRule(ctx, uuid)
+}
@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// NetworkingSubnet defines the NetworkingSubnet resource template
+func NetworkingSubnet() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		string(ResourceURIPrefix(ResourceTypeNetworkingSubnet))+"{extId}{?fields,detail,jq,profile}",
+		string(ResourceTypeNetworkingSubnet),
+		mcp.WithTemplateDescription("Subnet resource (v4 networking API), addressed by extId"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// NetworkingSubnetHandler implements the handler for the NetworkingSubnet resource
+func NetworkingSubnetHandler() server.ResourceTemplateHandlerFunc {
+	return CreateResourceHandler(ResourceTypeNetworkingSubnet, GetNetworkingSubnet)
+}
+
+// GetNetworkingSubnet gets a NetworkingSubnet by its extId
+func GetNetworkingSubnet(ctx context.Context, client *client.NutanixClient, extID string) (interface{}, error) {
+	v4Client, err := client.V4()
+	if err != nil {
+		return nil, err
+	}
+
+	return v4Client.SubnetsApiInstance.GetSubnetById(&extID)
+}
@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Project defines the Project resource template
+func Project() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		string(ResourceURIPrefix(ResourceTypeProject))+"{uuid}{?fields,detail,jq,profile}",
+		string(ResourceTypeProject),
+		mcp.WithTemplateDescription("Project resource"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// ProjectHandler implements the handler for the Project resource
+func ProjectHandler() server.ResourceTemplateHandlerFunc {
+	return CreateResourceHandler(ResourceTypeProject, GetProject)
+}
+
+// GetProject gets a Project by its UUID
+func GetProject(ctx context.Context, client *client.NutanixClient, uuid string) (interface{}, error) {
+	v3Client, err := client.V3()
+	if err != nil {
+		return nil, err
+	}
+
+	return v3Client.GetProject(ctx, uuid)
+}
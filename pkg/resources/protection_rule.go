@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ProtectionRule defines the ProtectionRule resource template
+func ProtectionRule() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		string(ResourceURIPrefix(ResourceTypeProtectionRule))+"{uuid}{?fields,detail,jq,profile}",
+		string(ResourceTypeProtectionRule),
+		mcp.WithTemplateDescription("Protection Rule resource"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// ProtectionRuleHandler implements the handler for the ProtectionRule resource
+func ProtectionRuleHandler() server.ResourceTemplateHandlerFunc {
+	return CreateResourceHandler(ResourceTypeProtectionRule, GetProtectionRule)
+}
+
+// GetProtectionRule gets a ProtectionRule by its UUID
+func GetProtectionRule(ctx context.Context, client *client.NutanixClient, uuid string) (interface{}, error) {
+	v3Client, err := client.V3()
+	if err != nil {
+		return nil, err
+	}
+
+	return v3Client.GetProtectionRule(ctx, uuid)
+}
@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RecoveryPlan defines the RecoveryPlan resource template
+func RecoveryPlan() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		string(ResourceURIPrefix(ResourceTypeRecoveryPlan))+"{uuid}{?fields,detail,jq,profile}",
+		string(ResourceTypeRecoveryPlan),
+		mcp.WithTemplateDescription("Recovery Plan resource"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// RecoveryPlanHandler implements the handler for the RecoveryPlan resource
+func RecoveryPlanHandler() server.ResourceTemplateHandlerFunc {
+	return CreateResourceHandler(ResourceTypeRecoveryPlan, GetRecoveryPlan)
+}
+
+// GetRecoveryPlan gets a RecoveryPlan by its UUID
+func GetRecoveryPlan(ctx context.Context, client *client.NutanixClient, uuid string) (interface{}, error) {
+	v3Client, err := client.V3()
+	if err != nil {
+		return nil, err
+	}
+
+	return v3Client.GetRecoveryPlan(ctx, uuid)
+}
@@ -0,0 +1,32 @@
+package resources
+
+import (
+	"github.com/thunderboltsid/mcp-nutanix/internal/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// RedactionsFragment is the fragment of the resource URI addressing the redaction audit of a resource
+const RedactionsFragment = "redactions"
+
+// redactionContents returns the audit of the secrets masked in a resource as an extra content,
+// addressed by the resource URI with the RedactionsFragment, or nothing if no secret was masked
+func redactionContents(uri string, counts json.RedactionCounts) []mcp.ResourceContents {
+	audit := json.NewRedactionAudit(counts)
+	if audit == nil {
+		return nil
+	}
+
+	auditBytes, err := json.RegularJSONEncoder(audit).MarshalJSON()
+	if err != nil {
+		return nil
+	}
+
+	return []mcp.ResourceContents{
+		&mcp.TextResourceContents{
+			URI:      uri + "#" + RedactionsFragment,
+			MIMEType: "application/json",
+			Text:     string(auditBytes),
+		},
+	}
+}
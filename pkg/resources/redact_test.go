@@ -0,0 +1,41 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceReportsRedactions(t *testing.T) {
+	client.InitProfile("redact", client.NewMCPModelContextClient(map[string]string{"endpoint": "10.0.0.1"}))
+
+	handler := CreateResourceHandler(ResourceTypeUser, func(ctx context.Context, _ *client.NutanixClient, uuid string) (interface{}, error) {
+		return map[string]interface{}{"name": "admin", "password": "nutanix/4u"}, nil
+	})
+	request := mcp.ReadResourceRequest{}
+	request.Params.URI = "user://u1?profile=redact"
+
+	contents, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, contents, 2)
+
+	resource := contents[0].(*mcp.TextResourceContents)
+	assert.JSONEq(t, `{"name":"admin","password":"[REDACTED]"}`, resource.Text)
+
+	audit := contents[1].(*mcp.TextResourceContents)
+	assert.Equal(t, "user://u1?profile=redact#redactions", audit.URI)
+	assert.JSONEq(t, `{"redacted":1,"rules":{"password":1}}`, audit.Text)
+
+	// Resources without secrets have no audit
+	handler = CreateResourceHandler(ResourceTypeUser, func(ctx context.Context, _ *client.NutanixClient, uuid string) (interface{}, error) {
+		return map[string]interface{}{"name": "admin"}, nil
+	})
+	contents, err = handler(context.Background(), request)
+	require.NoError(t, err)
+	assert.Len(t, contents, 1)
+}
@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Role defines the Role resource template
+func Role() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		string(ResourceURIPrefix(ResourceTypeRole))+"{uuid}{?fields,detail,jq,profile}",
+		string(ResourceTypeRole),
+		mcp.WithTemplateDescription("Role resource"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// RoleHandler implements the handler for the Role resource
+func RoleHandler() server.ResourceTemplateHandlerFunc {
+	return CreateResourceHandler(ResourceTypeRole, GetRole)
+}
+
+// GetRole gets a Role by its UUID
+func GetRole(ctx context.Context, client *client.NutanixClient, uuid string) (interface{}, error) {
+	v3Client, err := client.V3()
+	if err != nil {
+		return nil, err
+	}
+
+	return v3Client.GetRole(ctx, uuid)
+}
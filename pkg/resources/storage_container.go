@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// StorageContainer defines the StorageContainer resource template
+func StorageContainer() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		string(ResourceURIPrefix(ResourceTypeStorageContainer))+"{extId}{?fields,detail,jq,profile}",
+		string(ResourceTypeStorageContainer),
+		mcp.WithTemplateDescription("Storage Container resource (v4 storage API), addressed by extId"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// StorageContainerHandler implements the handler for the StorageContainer resource
+func StorageContainerHandler() server.ResourceTemplateHandlerFunc {
+	return CreateResourceHandler(ResourceTypeStorageContainer, GetStorageContainer)
+}
+
+// GetStorageContainer gets a StorageContainer by its extId
+func GetStorageContainer(ctx context.Context, client *client.NutanixClient, extID string) (interface{}, error) {
+	v4Client, err := client.V4()
+	if err != nil {
+		return nil, err
+	}
+
+	return v4Client.StorageContainerAPI.GetStorageContainerByExtId(&extID)
+}
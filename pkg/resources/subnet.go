@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Subnet defines the Subnet resource template
+func Subnet() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		string(ResourceURIPrefix(ResourceTypeSubnet))+"{uuid}{?fields,detail,jq,profile}",
+		string(ResourceTypeSubnet),
+		mcp.WithTemplateDescription("Subnet resource"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// SubnetHandler implements the handler for the Subnet resource
+func SubnetHandler() server.ResourceTemplateHandlerFunc {
+	return CreateResourceHandler(ResourceTypeSubnet, GetSubnet)
+}
+
+// GetSubnet gets a Subnet by its UUID
+func GetSubnet(ctx context.Context, client *client.NutanixClient, uuid string) (interface{}, error) {
+	v3Client, err := client.V3()
+	if err != nil {
+		return nil, err
+	}
+
+	return v3Client.GetSubnet(ctx, uuid)
+}
@@ -0,0 +1,307 @@
+package resources
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+)
+
+const (
+	SubResourceDisks = "disks"
+	SubResourceNics  = "nics"
+	SubResourceVMs   = "vms"
+	SubResourceIPs   = "ips"
+	SubResourceGraph = "graph"
+)
+
+const (
+	// vmPageLength is the number of VMs fetched per request when scanning VMs
+	vmPageLength int64 = 250
+	// maxScannedVMs caps the VMs listed by a scan, so that reading a sub-resource does not list every VM of a large Prism Central
+	maxScannedVMs int64 = 5000
+	// maxClusterVMs caps the VMs returned by the cluster VMs sub-resource
+	maxClusterVMs = 500
+	// maxSubnetIPs caps the addresses returned by the subnet IPs sub-resource
+	maxSubnetIPs = 500
+)
+
+// clusterNamePattern matches the cluster names that can be used verbatim in a VM list filter
+var clusterNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// subResources lists the sub-resources that can follow the id of each resource type
+var subResources = map[ResourceType][]string{
+	ResourceTypeVM:      {SubResourceDisks, SubResourceNics, SubResourceGraph},
+	ResourceTypeCluster: {SubResourceVMs},
+	ResourceTypeSubnet:  {SubResourceIPs},
+}
+
+// subResourceTemplate defines the resource template of a sub-resource
+func subResourceTemplate(resourceType ResourceType, subResource string, description string) mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		string(ResourceURIPrefix(resourceType))+"{uuid}/"+subResource+"{?fields,detail,jq,profile}",
+		string(resourceType)+"_"+subResource,
+		mcp.WithTemplateDescription(description),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// VMDisks defines the VM disks sub-resource template
+func VMDisks() mcp.ResourceTemplate {
+	return subResourceTemplate(ResourceTypeVM, SubResourceDisks, "Disks attached to a Virtual Machine")
+}
+
+// VMDisksHandler implements the handler for the VM disks sub-resource
+func VMDisksHandler() server.ResourceTemplateHandlerFunc {
+	return CreateSubResourceHandler(ResourceTypeVM, SubResourceDisks, func(ctx context.Context, client *client.NutanixClient, uuid string) (interface{}, error) {
+		v3Client, err := client.V3()
+		if err != nil {
+			return nil, err
+		}
+
+		vm, err := v3Client.GetVM(ctx, uuid)
+		if err != nil {
+			return nil, err
+		}
+
+		var disks []*v3.VMDisk
+		if vm.Status != nil && vm.Status.Resources != nil {
+			disks = vm.Status.Resources.DiskList
+		}
+
+		return map[string]interface{}{
+			"vm_uuid":   uuid,
+			"vm_name":   vmName(vm),
+			"disk_list": disks,
+		}, nil
+	})
+}
+
+// VMNics defines the VM NICs sub-resource template
+func VMNics() mcp.ResourceTemplate {
+	return subResourceTemplate(ResourceTypeVM, SubResourceNics, "NICs of a Virtual Machine with their subnets and IP addresses")
+}
+
+// VMNicsHandler implements the handler for the VM NICs sub-resource
+func VMNicsHandler() server.ResourceTemplateHandlerFunc {
+	return CreateSubResourceHandler(ResourceTypeVM, SubResourceNics, func(ctx context.Context, client *client.NutanixClient, uuid string) (interface{}, error) {
+		v3Client, err := client.V3()
+		if err != nil {
+			return nil, err
+		}
+
+		vm, err := v3Client.GetVM(ctx, uuid)
+		if err != nil {
+			return nil, err
+		}
+
+		var nics []*v3.VMNicOutputStatus
+		if vm.Status != nil && vm.Status.Resources != nil {
+			nics = vm.Status.Resources.NicList
+		}
+
+		return map[string]interface{}{
+			"vm_uuid":  uuid,
+			"vm_name":  vmName(vm),
+			"nic_list": nics,
+		}, nil
+	})
+}
+
+// ClusterVMs defines the cluster VMs sub-resource template
+func ClusterVMs() mcp.ResourceTemplate {
+	return subResourceTemplate(ResourceTypeCluster, SubResourceVMs, "Virtual Machines running on a cluster")
+}
+
+// ClusterVMsHandler implements the handler for the cluster VMs sub-resource
+func ClusterVMsHandler() server.ResourceTemplateHandlerFunc {
+	return CreateSubResourceHandler(ResourceTypeCluster, SubResourceVMs, func(ctx context.Context, client *client.NutanixClient, uuid string) (interface{}, error) {
+		v3Client, err := client.V3()
+		if err != nil {
+			return nil, err
+		}
+
+		cluster, err := v3Client.GetCluster(ctx, uuid)
+		if err != nil {
+			return nil, err
+		}
+
+		// Let Prism Central narrow the VMs down by cluster name, the UUID check drops other clusters matching the name
+		entities := make([]*v3.VMIntentResource, 0)
+		truncated := false
+		capped, err := scanVMs(ctx, v3Client, clusterVMsFilter(cluster), func(vm *v3.VMIntentResource) bool {
+			if vm.Status == nil || vm.Status.ClusterReference == nil || stringValue(vm.Status.ClusterReference.UUID) != uuid {
+				return true
+			}
+			if len(entities) == maxClusterVMs {
+				truncated = true
+				return false
+			}
+			entities = append(entities, vm)
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		res := map[string]interface{}{
+			"cluster_uuid": uuid,
+			"count":        len(entities),
+			"entities":     entities,
+		}
+		if truncated || capped {
+			res["entities_truncated"] = true
+		}
+
+		return res, nil
+	})
+}
+
+// SubnetIPs defines the subnet IPs sub-resource template
+func SubnetIPs() mcp.ResourceTemplate {
+	return subResourceTemplate(ResourceTypeSubnet, SubResourceIPs, "IP configuration of a subnet and the VM NICs holding its addresses")
+}
+
+// SubnetIPsHandler implements the handler for the subnet IPs sub-resource
+func SubnetIPsHandler() server.ResourceTemplateHandlerFunc {
+	return CreateSubResourceHandler(ResourceTypeSubnet, SubResourceIPs, func(ctx context.Context, client *client.NutanixClient, uuid string) (interface{}, error) {
+		v3Client, err := client.V3()
+		if err != nil {
+			return nil, err
+		}
+
+		subnet, err := v3Client.GetSubnet(ctx, uuid)
+		if err != nil {
+			return nil, err
+		}
+
+		// VMs cannot be filtered by subnet, so they are scanned until either cap is reached
+		assigned := make([]map[string]interface{}, 0)
+		truncated := false
+		capped, err := scanVMs(ctx, v3Client, "", func(vm *v3.VMIntentResource) bool {
+			for _, ip := range subnetIPs(vm, uuid) {
+				if len(assigned) == maxSubnetIPs {
+					truncated = true
+					return false
+				}
+				assigned = append(assigned, ip)
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		res := map[string]interface{}{
+			"subnet_uuid":  uuid,
+			"assigned_ips": assigned,
+		}
+		if truncated || capped {
+			res["assigned_ips_truncated"] = true
+		}
+		if subnet.Status != nil && subnet.Status.Resources != nil {
+			res["subnet_name"] = stringValue(subnet.Status.Name)
+			res["ip_config"] = subnet.Status.Resources.IPConfig
+			res["ip_usage_stats"] = subnet.Status.Resources.IPUsageStats
+			res["reserved_ip_address_list"] = subnet.Status.Resources.ReservedIPAddressList
+		}
+
+		return res, nil
+	})
+}
+
+// scanVMs lists the VMs matching a filter a page at a time and passes each one to visit until it returns false.
+// At most maxScannedVMs VMs are listed; it reports whether VMs were left unscanned because of that cap.
+func scanVMs(ctx context.Context, v3Client v3.Service, filter string, visit func(vm *v3.VMIntentResource) bool) (bool, error) {
+	kind := "vm"
+	for offset := int64(0); ; {
+		page, length := offset, min(vmPageLength, maxScannedVMs-offset)
+		metadata := &v3.DSMetadata{Kind: &kind, Offset: &page, Length: &length}
+		if filter != "" {
+			metadata.Filter = &filter
+		}
+
+		vms, err := v3Client.ListVM(ctx, metadata)
+		if err != nil {
+			return false, err
+		}
+		for _, vm := range vms.Entities {
+			if !visit(vm) {
+				return false, nil
+			}
+		}
+
+		offset += length
+		if vms.Metadata == nil || vms.Metadata.TotalMatches == nil || offset >= *vms.Metadata.TotalMatches || len(vms.Entities) == 0 {
+			return false, nil
+		}
+		if offset >= maxScannedVMs {
+			return true, nil
+		}
+	}
+}
+
+// clusterVMsFilter returns the FIQL filter narrowing the VMs down to a cluster by its name. Prism Central matches
+// the value as a regular expression, so only names whose characters match themselves (or, like '.', a superset)
+// are used. Any other name gives no filter and the VMs are selected by cluster UUID only.
+func clusterVMsFilter(cluster *v3.ClusterIntentResponse) string {
+	var name string
+	switch {
+	case cluster.Status != nil && cluster.Status.Name != "":
+		name = cluster.Status.Name
+	case cluster.Spec != nil:
+		name = cluster.Spec.Name
+	}
+	if !clusterNamePattern.MatchString(name) {
+		return ""
+	}
+
+	return "cluster_name==" + name
+}
+
+// subnetIPs returns the addresses a VM holds on a subnet
+func subnetIPs(vm *v3.VMIntentResource, subnetUUID string) []map[string]interface{} {
+	if vm.Metadata == nil || vm.Status == nil || vm.Status.Resources == nil {
+		return nil
+	}
+
+	var ips []map[string]interface{}
+	for _, nic := range vm.Status.Resources.NicList {
+		if nic.SubnetReference == nil || stringValue(nic.SubnetReference.UUID) != subnetUUID {
+			continue
+		}
+		for _, ip := range nic.IPEndpointList {
+			ips = append(ips, map[string]interface{}{
+				"ip":          stringValue(ip.IP),
+				"type":        stringValue(ip.Type),
+				"mac_address": stringValue(nic.MacAddress),
+				"nic_uuid":    stringValue(nic.UUID),
+				"vm_uuid":     stringValue(vm.Metadata.UUID),
+				"vm_name":     stringValue(vm.Status.Name),
+			})
+		}
+	}
+
+	return ips
+}
+
+func vmName(vm *v3.VMIntentResponse) string {
+	if vm.Status != nil && vm.Status.Name != nil {
+		return *vm.Status.Name
+	}
+	if vm.Spec != nil && vm.Spec.Name != nil {
+		return *vm.Spec.Name
+	}
+	return ""
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,108 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+	"github.com/nutanix-cloud-native/prism-go-client/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterVMsFilter(t *testing.T) {
+	cluster := func(name string) *v3.ClusterIntentResponse {
+		return &v3.ClusterIntentResponse{Status: &models.ClusterDefStatus{Name: name}}
+	}
+
+	assert.Equal(t, "cluster_name==prod-01", clusterVMsFilter(cluster("prod-01")))
+	assert.Equal(t, "cluster_name==lab", clusterVMsFilter(&v3.ClusterIntentResponse{Spec: &models.Cluster{Name: "lab"}}))
+	assert.Equal(t, "cluster_name==prod.eu_1", clusterVMsFilter(cluster("prod.eu_1")))
+	// Names that would break the FIQL expression or match as a different regular expression select by UUID only
+	assert.Equal(t, "", clusterVMsFilter(cluster("prod;01")))
+	assert.Equal(t, "", clusterVMsFilter(cluster("prod(1)")))
+	assert.Equal(t, "", clusterVMsFilter(cluster("a+b")))
+	assert.Equal(t, "", clusterVMsFilter(cluster("lab 2")))
+	assert.Equal(t, "", clusterVMsFilter(&v3.ClusterIntentResponse{}))
+}
+
+func TestSubnetIPs(t *testing.T) {
+	str := func(s string) *string { return &s }
+	vm := &v3.VMIntentResource{
+		Metadata: &v3.Metadata{UUID: str("vm-1")},
+		Status: &v3.VMDefStatus{
+			Name: str("web-01"),
+			Resources: &v3.VMResourcesDefStatus{
+				NicList: []*v3.VMNicOutputStatus{
+					{SubnetReference: &v3.Reference{UUID: str("subnet-a")}, IPEndpointList: []*v3.IPAddress{{IP: str("10.0.0.5")}}},
+					{SubnetReference: &v3.Reference{UUID: str("subnet-b")}, IPEndpointList: []*v3.IPAddress{{IP: str("10.1.0.5")}}},
+				},
+			},
+		},
+	}
+
+	ips := subnetIPs(vm, "subnet-a")
+	assert.Len(t, ips, 1)
+	assert.Equal(t, "10.0.0.5", ips[0]["ip"])
+	assert.Equal(t, "web-01", ips[0]["vm_name"])
+	assert.Empty(t, subnetIPs(&v3.VMIntentResource{}, "subnet-a"))
+
+	vm.Metadata = nil
+	assert.Empty(t, subnetIPs(vm, "subnet-a"))
+}
+
+// vmLister is a v3 service listing a number of VMs, its other methods are not implemented
+type vmLister struct {
+	v3.Service
+	total int64
+	calls int
+}
+
+func (l *vmLister) ListVM(ctx context.Context, metadata *v3.DSMetadata) (*v3.VMListIntentResponse, error) {
+	l.calls++
+	n := min(*metadata.Length, l.total-*metadata.Offset)
+	entities := make([]*v3.VMIntentResource, max(n, 0))
+	for i := range entities {
+		entities[i] = &v3.VMIntentResource{}
+	}
+	return &v3.VMListIntentResponse{Entities: entities, Metadata: &v3.ListMetadataOutput{TotalMatches: &l.total}}, nil
+}
+
+func TestScanVMs(t *testing.T) {
+	visit := func(count *int64) func(vm *v3.VMIntentResource) bool {
+		return func(vm *v3.VMIntentResource) bool {
+			*count++
+			return true
+		}
+	}
+
+	// Small deployments are scanned completely
+	var scanned int64
+	lister := &vmLister{total: 600}
+	capped, err := scanVMs(context.Background(), lister, "", visit(&scanned))
+	require.NoError(t, err)
+	assert.False(t, capped)
+	assert.Equal(t, int64(600), scanned)
+	assert.Equal(t, 3, lister.calls)
+
+	// Large deployments stop at the cap and report it
+	scanned = 0
+	lister = &vmLister{total: 100000}
+	capped, err = scanVMs(context.Background(), lister, "", visit(&scanned))
+	require.NoError(t, err)
+	assert.True(t, capped)
+	assert.Equal(t, maxScannedVMs, scanned)
+	assert.Equal(t, int(maxScannedVMs/vmPageLength), lister.calls)
+
+	// Exactly the cap leaves nothing unscanned
+	scanned = 0
+	capped, err = scanVMs(context.Background(), &vmLister{total: maxScannedVMs}, "", visit(&scanned))
+	require.NoError(t, err)
+	assert.False(t, capped)
+	assert.Equal(t, maxScannedVMs, scanned)
+
+	// Scans stopped by visit are not capped
+	capped, err = scanVMs(context.Background(), &vmLister{total: 100000}, "", func(vm *v3.VMIntentResource) bool { return false })
+	require.NoError(t, err)
+	assert.False(t, capped)
+}
@@ -0,0 +1,197 @@
+package resources
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+)
+
+// View selects how a resource is represented
+type View string
+
+const (
+	// ViewFull returns the resource as returned by the API
+	ViewFull View = "full"
+	// ViewSummary returns a derived, flattened record of the fields most questions need
+	ViewSummary View = "summary"
+)
+
+// SummaryFunc converts a get or list response of a resource type into its summary view
+type SummaryFunc func(resource interface{}) (interface{}, error)
+
+// summaries lists the resource types supporting the summary view
+var summaries = map[ResourceType]SummaryFunc{
+	ResourceTypeVM: summarizeVMs,
+}
+
+// HasSummaryView reports whether a resource type supports the summary view
+func HasSummaryView(resourceType ResourceType) bool {
+	_, ok := summaries[resourceType]
+	return ok
+}
+
+// ParseView validates a view name for a resource type, defaulting to the full view
+func ParseView(resourceType ResourceType, raw string) (View, error) {
+	switch View(strings.ToLower(strings.TrimSpace(raw))) {
+	case "", ViewFull:
+		return ViewFull, nil
+	case ViewSummary:
+		if !HasSummaryView(resourceType) {
+			return "", fmt.Errorf("view %q is not supported for %s", ViewSummary, resourceType)
+		}
+		return ViewSummary, nil
+	default:
+		return "", fmt.Errorf("unsupported view %q, expected %s or %s", raw, ViewFull, ViewSummary)
+	}
+}
+
+// ApplyView converts a get or list response into the requested view
+func ApplyView(resourceType ResourceType, view View, resource interface{}) (interface{}, error) {
+	if view != ViewSummary {
+		return resource, nil
+	}
+
+	summarize, ok := summaries[resourceType]
+	if !ok {
+		return nil, fmt.Errorf("view %q is not supported for %s", ViewSummary, resourceType)
+	}
+
+	return summarize(resource)
+}
+
+// VMSummary is the flattened summary view of a VM
+type VMSummary struct {
+	Name         string            `json:"name"`
+	UUID         string            `json:"uuid"`
+	PowerState   string            `json:"power_state,omitempty"`
+	Cluster      string            `json:"cluster,omitempty"`
+	Host         string            `json:"host,omitempty"`
+	VCPUs        int64             `json:"vcpus"`
+	MemoryGiB    float64           `json:"memory_gib"`
+	DiskGiB      float64           `json:"disk_gib"`
+	IPs          []string          `json:"ips"`
+	Categories   map[string]string `json:"categories,omitempty"`
+	CreationTime *time.Time        `json:"creation_time,omitempty"`
+}
+
+// VMSummaryList is the summary view of a page of VMs
+type VMSummaryList struct {
+	Metadata *v3.ListMetadataOutput `json:"metadata,omitempty"`
+	Entities []*VMSummary           `json:"entities"`
+}
+
+// summarizeVMs converts a VM get or list response into its summary view
+func summarizeVMs(resource interface{}) (interface{}, error) {
+	switch vm := resource.(type) {
+	case *v3.VMIntentResponse:
+		return SummarizeVM(vm.Metadata, vm.Spec, vm.Status), nil
+	case *v3.VMIntentResource:
+		return SummarizeVM(vm.Metadata, vm.Spec, vm.Status), nil
+	case *v3.VMListIntentResponse:
+		list := &VMSummaryList{Metadata: vm.Metadata, Entities: make([]*VMSummary, 0, len(vm.Entities))}
+		for _, entity := range vm.Entities {
+			list.Entities = append(list.Entities, SummarizeVM(entity.Metadata, entity.Spec, entity.Status))
+		}
+		return list, nil
+	default:
+		return nil, fmt.Errorf("cannot summarize %T as a VM", resource)
+	}
+}
+
+// SummarizeVM derives the summary view of a VM, preferring the observed status over the spec
+func SummarizeVM(metadata *v3.Metadata, spec *v3.VM, status *v3.VMDefStatus) *VMSummary {
+	summary := &VMSummary{IPs: []string{}}
+
+	if metadata != nil {
+		summary.UUID = stringValue(metadata.UUID)
+		summary.Categories = metadata.Categories
+		summary.CreationTime = metadata.CreationTime
+	}
+
+	if spec != nil {
+		summary.Name = stringValue(spec.Name)
+		if spec.ClusterReference != nil {
+			summary.Cluster = stringValue(spec.ClusterReference.Name)
+		}
+		if resources := spec.Resources; resources != nil {
+			summary.PowerState = stringValue(resources.PowerState)
+			summary.VCPUs = int64Value(resources.NumSockets) * int64Value(resources.NumVcpusPerSocket)
+			summary.MemoryGiB = mibToGiB(int64Value(resources.MemorySizeMib))
+			summary.DiskGiB = diskGiB(resources.DiskList)
+		}
+	}
+
+	if status != nil {
+		if name := stringValue(status.Name); name != "" {
+			summary.Name = name
+		}
+		if status.ClusterReference != nil && status.ClusterReference.Name != nil {
+			summary.Cluster = *status.ClusterReference.Name
+		}
+		if resources := status.Resources; resources != nil {
+			if resources.PowerState != nil {
+				summary.PowerState = *resources.PowerState
+			}
+			if resources.HostReference != nil {
+				summary.Host = stringValue(resources.HostReference.Name)
+			}
+			if resources.NumSockets != nil {
+				summary.VCPUs = int64Value(resources.NumSockets) * int64Value(resources.NumVcpusPerSocket)
+			}
+			if resources.MemorySizeMib != nil {
+				summary.MemoryGiB = mibToGiB(*resources.MemorySizeMib)
+			}
+			if resources.DiskList != nil {
+				summary.DiskGiB = diskGiB(resources.DiskList)
+			}
+			for _, nic := range resources.NicList {
+				for _, ip := range nic.IPEndpointList {
+					if address := stringValue(ip.IP); address != "" {
+						summary.IPs = append(summary.IPs, address)
+					}
+				}
+			}
+		}
+	}
+	sort.Strings(summary.IPs)
+
+	return summary
+}
+
+// diskGiB sums the sizes of the disks, leaving out CD-ROMs
+func diskGiB(disks []*v3.VMDisk) float64 {
+	var bytes int64
+	for _, disk := range disks {
+		if disk.DeviceProperties != nil && stringValue(disk.DeviceProperties.DeviceType) == "CDROM" {
+			continue
+		}
+		switch {
+		case disk.DiskSizeBytes != nil:
+			bytes += *disk.DiskSizeBytes
+		case disk.DiskSizeMib != nil:
+			bytes += *disk.DiskSizeMib << 20
+		}
+	}
+
+	return roundGiB(float64(bytes) / (1 << 30))
+}
+
+func mibToGiB(mib int64) float64 {
+	return roundGiB(float64(mib) / 1024)
+}
+
+// roundGiB rounds a size to two decimals
+func roundGiB(gib float64) float64 {
+	return math.Round(gib*100) / 100
+}
+
+func int64Value(i *int64) int64 {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
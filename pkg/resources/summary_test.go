@@ -0,0 +1,82 @@
+package resources
+
+import (
+	"testing"
+	"time"
+
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeVM(t *testing.T) {
+	name, uuid, power, cluster, host := "web-01", "vm-1", "ON", "cluster-a", "host-3"
+	sockets, cores, memory := int64(2), int64(4), int64(6144)
+	diskBytes, cdromBytes, diskMib := int64(40<<30), int64(4<<30), int64(10240)
+	ip1, ip2, cdrom := "10.0.0.12", "10.0.0.11", "CDROM"
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	vm := &v3.VMIntentResponse{
+		Metadata: &v3.Metadata{UUID: &uuid, CreationTime: &created, Categories: map[string]string{"Environment": "Production"}},
+		Spec:     &v3.VM{Name: &name},
+		Status: &v3.VMDefStatus{
+			Name:             &name,
+			ClusterReference: &v3.Reference{Name: &cluster},
+			Resources: &v3.VMResourcesDefStatus{
+				PowerState:        &power,
+				HostReference:     &v3.Reference{Name: &host},
+				NumSockets:        &sockets,
+				NumVcpusPerSocket: &cores,
+				MemorySizeMib:     &memory,
+				DiskList: []*v3.VMDisk{
+					{DiskSizeBytes: &diskBytes},
+					{DiskSizeMib: &diskMib},
+					{DiskSizeBytes: &cdromBytes, DeviceProperties: &v3.VMDiskDeviceProperties{DeviceType: &cdrom}},
+				},
+				NicList: []*v3.VMNicOutputStatus{
+					{IPEndpointList: []*v3.IPAddress{{IP: &ip1}}},
+					{IPEndpointList: []*v3.IPAddress{{IP: &ip2}}},
+				},
+			},
+		},
+	}
+
+	summary, err := ApplyView(ResourceTypeVM, ViewSummary, vm)
+	require.NoError(t, err)
+	assert.Equal(t, &VMSummary{
+		Name:         "web-01",
+		UUID:         "vm-1",
+		PowerState:   "ON",
+		Cluster:      "cluster-a",
+		Host:         "host-3",
+		VCPUs:        8,
+		MemoryGiB:    6,
+		DiskGiB:      50,
+		IPs:          []string{"10.0.0.11", "10.0.0.12"},
+		Categories:   map[string]string{"Environment": "Production"},
+		CreationTime: &created,
+	}, summary)
+
+	list, err := ApplyView(ResourceTypeVM, ViewSummary, &v3.VMListIntentResponse{
+		Entities: []*v3.VMIntentResource{{Metadata: vm.Metadata, Spec: vm.Spec, Status: vm.Status}},
+	})
+	require.NoError(t, err)
+	require.Len(t, list.(*VMSummaryList).Entities, 1)
+	assert.Equal(t, summary, list.(*VMSummaryList).Entities[0])
+}
+
+func TestParseView(t *testing.T) {
+	view, err := ParseView(ResourceTypeCluster, "")
+	require.NoError(t, err)
+	assert.Equal(t, ViewFull, view)
+
+	view, err = ParseView(ResourceTypeVM, "Summary")
+	require.NoError(t, err)
+	assert.Equal(t, ViewSummary, view)
+
+	_, err = ParseView(ResourceTypeCluster, "summary")
+	assert.ErrorContains(t, err, "not supported for cluster")
+
+	_, err = ParseView(ResourceTypeVM, "brief")
+	assert.ErrorContains(t, err, "expected full or summary")
+}
@@ -0,0 +1,104 @@
+package resources
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+)
+
+// resourceTypePattern matches the scheme part of a resource URI
+var resourceTypePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// ResourceURI is a parsed resource URI of the form resourceType://id[/subResource][?query]
+type ResourceURI struct {
+	Type        ResourceType
+	ID          string
+	SubResource string
+	Query       url.Values
+}
+
+// ParseResourceURI parses a resource URI and rejects malformed ones with a descriptive error
+func ParseResourceURI(uri string) (*ResourceURI, error) {
+	scheme, rest, found := strings.Cut(uri, "://")
+	if !found {
+		return nil, fmt.Errorf("malformed resource URI %q: expected <type>://<id>[/<sub-resource>], e.g. vm://{uuid}/disks", uri)
+	}
+	if !resourceTypePattern.MatchString(scheme) {
+		return nil, fmt.Errorf("malformed resource URI %q: invalid resource type %q", uri, scheme)
+	}
+
+	path, rawQuery, _ := strings.Cut(rest, "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("malformed resource URI %q: invalid query: %w", uri, err)
+	}
+
+	segments := strings.Split(path, "/")
+	if len(segments) > 2 {
+		return nil, fmt.Errorf("malformed resource URI %q: expected at most one sub-resource after the id", uri)
+	}
+
+	id, err := url.PathUnescape(segments[0])
+	if err != nil || id == "" {
+		return nil, fmt.Errorf("malformed resource URI %q: missing or invalid id", uri)
+	}
+
+	parsed := &ResourceURI{
+		Type:  ResourceType(scheme),
+		ID:    id,
+		Query: query,
+	}
+
+	if len(segments) == 2 {
+		parsed.SubResource = segments[1]
+		if !isSubResource(parsed.Type, parsed.SubResource) {
+			return nil, fmt.Errorf("malformed resource URI %q: unknown sub-resource %q for %s, supported: %s",
+				uri, parsed.SubResource, parsed.Type, supportedSubResources(parsed.Type))
+		}
+	}
+
+	return parsed, nil
+}
+
+// ProfileURI selects the connection profile a resource URI is read from, e.g. vm://{uuid}?profile=dr.
+// URIs of the default profile are returned unchanged.
+func ProfileURI(uri string, profile string) string {
+	if uri == "" || profile == "" || profile == client.DefaultProfile {
+		return uri
+	}
+
+	separator := "?"
+	if strings.Contains(uri, "?") {
+		separator = "&"
+	}
+	return uri + separator + "profile=" + url.QueryEscape(profile)
+}
+
+// NutanixSubResourceURI returns a URI for a sub-resource of a resource
+func NutanixSubResourceURI(resourceType ResourceType, uuid string, subResource string) string {
+	return fmt.Sprintf("%s/%s", NutanixURI(resourceType, uuid), subResource)
+}
+
+func isSubResource(resourceType ResourceType, subResource string) bool {
+	for _, name := range subResources[resourceType] {
+		if name == subResource {
+			return true
+		}
+	}
+
+	return false
+}
+
+func supportedSubResources(resourceType ResourceType) string {
+	names := append([]string(nil), subResources[resourceType]...)
+	if len(names) == 0 {
+		return "none"
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,51 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseResourceURI(t *testing.T) {
+	uri, err := ParseResourceURI("vm://1234")
+	require.NoError(t, err)
+	assert.Equal(t, ResourceTypeVM, uri.Type)
+	assert.Equal(t, "1234", uri.ID)
+	assert.Empty(t, uri.SubResource)
+
+	uri, err = ParseResourceURI("vm://1234/disks?fields=disk_list")
+	require.NoError(t, err)
+	assert.Equal(t, "1234", uri.ID)
+	assert.Equal(t, SubResourceDisks, uri.SubResource)
+	assert.Equal(t, "disk_list", uri.Query.Get("fields"))
+
+	uri, err = ParseResourceURI("category://AppType%20Old")
+	require.NoError(t, err)
+	assert.Equal(t, "AppType Old", uri.ID)
+}
+
+func TestParseResourceURIErrors(t *testing.T) {
+	tests := map[string]string{
+		"1234":               "expected <type>://<id>",
+		"VM!://1234":         "invalid resource type",
+		"vm://":              "missing or invalid id",
+		"vm:///disks":        "missing or invalid id",
+		"vm://1234/disks/0":  "at most one sub-resource",
+		"vm://1234/vms":      "supported: disks, graph, nics",
+		"host://1234/disks":  "supported: none",
+		"vm://1234?fields=%": "invalid query",
+	}
+
+	for input, message := range tests {
+		_, err := ParseResourceURI(input)
+		require.Error(t, err, input)
+		assert.Contains(t, err.Error(), message, input)
+	}
+}
+
+func TestNutanixSubResourceURI(t *testing.T) {
+	assert.Equal(t, "cluster://1234/vms", NutanixSubResourceURI(ResourceTypeCluster, "1234", SubResourceVMs))
+	assert.Equal(t, ResourceTypeCluster, ExtractTypeFromURI("cluster://1234/vms"))
+	assert.Equal(t, "1234", ExtractIDFromURI("cluster://1234/vms"))
+}
@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// User defines the User resource template
+func User() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		string(ResourceURIPrefix(ResourceTypeUser))+"{uuid}{?fields,detail,jq,profile}",
+		string(ResourceTypeUser),
+		mcp.WithTemplateDescription("User resource"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// UserHandler implements the handler for the User resource
+func UserHandler() server.ResourceTemplateHandlerFunc {
+	return CreateResourceHandler(ResourceTypeUser, GetUser)
+}
+
+// GetUser gets a User by its UUID
+func GetUser(ctx context.Context, client *client.NutanixClient, uuid string) (interface{}, error) {
+	v3Client, err := client.V3()
+	if err != nil {
+		return nil, err
+	}
+
+	return v3Client.GetUser(ctx, uuid)
+}
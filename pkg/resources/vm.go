@@ -12,7 +12,7 @@ import (
 // VM defines the VM resource template
 func VM() mcp.ResourceTemplate {
 	return mcp.NewResourceTemplate(
-		string(ResourceURIPrefix(ResourceTypeVM))+"{uuid}",
+		string(ResourceURIPrefix(ResourceTypeVM))+"{uuid}{?fields,detail,view,jq,profile}",
 		string(ResourceTypeVM),
 		mcp.WithTemplateDescription("Virtual Machine resource"),
 		mcp.WithTemplateMIMEType("application/json"),
@@ -21,8 +21,15 @@ func VM() mcp.ResourceTemplate {
 
 // VMHandler implements the handler for the VM resource
 func VMHandler() server.ResourceTemplateHandlerFunc {
-	return CreateResourceHandler(ResourceTypeVM, func(ctx context.Context, client *client.NutanixClient, uuid string) (interface{}, error) {
-		// Get the VM
-		return client.V3().GetVM(ctx, uuid)
-	})
+	return CreateResourceHandler(ResourceTypeVM, GetVM)
+}
+
+// GetVM gets a VM by its UUID
+func GetVM(ctx context.Context, client *client.NutanixClient, uuid string) (interface{}, error) {
+	v3Client, err := client.V3()
+	if err != nil {
+		return nil, err
+	}
+
+	return v3Client.GetVM(ctx, uuid)
 }
@@ -0,0 +1,223 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+)
+
+// EntityRef is a resolved reference to a Nutanix entity
+type EntityRef struct {
+	Kind  string `json:"kind"`
+	UUID  string `json:"uuid"`
+	Name  string `json:"name,omitempty"`
+	URI   string `json:"uri,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// VMRelationGraph holds the entities a VM is attached to, resolved by name
+type VMRelationGraph struct {
+	VM           EntityRef         `json:"vm"`
+	Cluster      *EntityRef        `json:"cluster,omitempty"`
+	Host         *EntityRef        `json:"host,omitempty"`
+	Subnets      []*EntityRef      `json:"subnets"`
+	Images       []*EntityRef      `json:"images"`
+	VolumeGroups []*EntityRef      `json:"volume_groups"`
+	Project      *EntityRef        `json:"project,omitempty"`
+	Owner        *EntityRef        `json:"owner,omitempty"`
+	Categories   map[string]string `json:"categories,omitempty"`
+}
+
+// nameLookupFunc returns the name of the entity of the given kind and UUID
+type nameLookupFunc func(ctx context.Context, kind, uuid string) (string, error)
+
+// VMGraph defines the VM relationship graph sub-resource template
+func VMGraph() mcp.ResourceTemplate {
+	return subResourceTemplate(ResourceTypeVM, SubResourceGraph,
+		"Cluster, host, subnets, images, volume groups, categories, project and owner of a Virtual Machine, resolved by name")
+}
+
+// VMGraphHandler implements the handler for the VM relationship graph sub-resource
+func VMGraphHandler() server.ResourceTemplateHandlerFunc {
+	return CreateSubResourceHandler(ResourceTypeVM, SubResourceGraph, func(ctx context.Context, client *client.NutanixClient, uuid string) (interface{}, error) {
+		return ResolveVMGraph(ctx, client, uuid)
+	})
+}
+
+// ResolveVMGraph fetches a VM and resolves the names of all entities it references.
+// Entities that cannot be resolved keep the name from the reference and report the error.
+func ResolveVMGraph(ctx context.Context, client *client.NutanixClient, uuid string) (*VMRelationGraph, error) {
+	v3Client, err := client.V3()
+	if err != nil {
+		return nil, err
+	}
+
+	vm, err := v3Client.GetVM(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := newVMGraph(uuid, vm)
+	graph.resolve(ctx, v3NameLookup(v3Client))
+
+	// Read the referenced entities from the same connection profile
+	graph.VM.URI = ProfileURI(graph.VM.URI, client.Profile())
+	for _, ref := range graph.refs() {
+		if ref != nil {
+			ref.URI = ProfileURI(ref.URI, client.Profile())
+		}
+	}
+
+	return graph, nil
+}
+
+// newVMGraph builds the graph of a VM from its references, without resolving names
+func newVMGraph(uuid string, vm *v3.VMIntentResponse) *VMRelationGraph {
+	graph := &VMRelationGraph{
+		VM:           EntityRef{Kind: "vm", UUID: uuid, Name: vmName(vm), URI: NutanixURI(ResourceTypeVM, uuid)},
+		Subnets:      []*EntityRef{},
+		Images:       []*EntityRef{},
+		VolumeGroups: []*EntityRef{},
+	}
+	seen := map[string]struct{}{}
+
+	if vm.Metadata != nil {
+		graph.Project = newEntityRef(vm.Metadata.ProjectReference)
+		graph.Owner = newEntityRef(vm.Metadata.OwnerReference)
+		graph.Categories = vm.Metadata.Categories
+	}
+
+	if vm.Status == nil {
+		return graph
+	}
+	graph.Cluster = newEntityRef(vm.Status.ClusterReference)
+
+	if vm.Status.Resources == nil {
+		return graph
+	}
+	graph.Host = newEntityRef(vm.Status.Resources.HostReference)
+
+	for _, nic := range vm.Status.Resources.NicList {
+		graph.Subnets = appendUniqueRef(graph.Subnets, seen, nic.SubnetReference)
+	}
+	for _, disk := range vm.Status.Resources.DiskList {
+		if disk.DataSourceReference != nil && stringValue(disk.DataSourceReference.Kind) == "image" {
+			graph.Images = appendUniqueRef(graph.Images, seen, disk.DataSourceReference)
+		}
+		graph.VolumeGroups = appendUniqueRef(graph.VolumeGroups, seen, disk.VolumeGroupReference)
+	}
+
+	return graph
+}
+
+// resolve looks up the name of every referenced entity, querying each entity once
+func (g *VMRelationGraph) resolve(ctx context.Context, lookup nameLookupFunc) {
+	for _, ref := range g.refs() {
+		if ref == nil || ref.UUID == "" {
+			continue
+		}
+		name, err := lookup(ctx, ref.Kind, ref.UUID)
+		if err != nil {
+			ref.Error = err.Error()
+			continue
+		}
+		if name != "" {
+			ref.Name = name
+		}
+	}
+}
+
+// refs returns the references of the graph besides the VM itself, nil when absent
+func (g *VMRelationGraph) refs() []*EntityRef {
+	refs := []*EntityRef{g.Cluster, g.Host, g.Project, g.Owner}
+	refs = append(refs, g.Subnets...)
+	refs = append(refs, g.Images...)
+	return append(refs, g.VolumeGroups...)
+}
+
+// v3NameLookup returns a name lookup backed by the v3 API
+func v3NameLookup(v3Client v3.Service) nameLookupFunc {
+	return func(ctx context.Context, kind, uuid string) (string, error) {
+		switch kind {
+		case "cluster":
+			resp, err := v3Client.GetCluster(ctx, uuid)
+			if err != nil || resp.Status == nil {
+				return "", err
+			}
+			return resp.Status.Name, nil
+		case "host":
+			resp, err := v3Client.GetHost(ctx, uuid)
+			if err != nil || resp.Status == nil {
+				return "", err
+			}
+			return resp.Status.Name, nil
+		case "subnet":
+			resp, err := v3Client.GetSubnet(ctx, uuid)
+			if err != nil || resp.Status == nil {
+				return "", err
+			}
+			return stringValue(resp.Status.Name), nil
+		case "image":
+			resp, err := v3Client.GetImage(ctx, uuid)
+			if err != nil || resp.Status == nil {
+				return "", err
+			}
+			return stringValue(resp.Status.Name), nil
+		case "volume_group":
+			resp, err := v3Client.GetVolumeGroup(ctx, uuid)
+			if err != nil || resp.Status == nil {
+				return "", err
+			}
+			return stringValue(resp.Status.Name), nil
+		case "project":
+			resp, err := v3Client.GetProject(ctx, uuid)
+			if err != nil || resp.Status == nil {
+				return "", err
+			}
+			return resp.Status.Name, nil
+		case "user":
+			resp, err := v3Client.GetUser(ctx, uuid)
+			if err != nil || resp.Status == nil {
+				return "", err
+			}
+			return stringValue(resp.Status.Name), nil
+		default:
+			return "", fmt.Errorf("unsupported reference kind %s", kind)
+		}
+	}
+}
+
+func newEntityRef(ref *v3.Reference) *EntityRef {
+	if ref == nil || ref.UUID == nil {
+		return nil
+	}
+
+	entity := &EntityRef{
+		Kind: stringValue(ref.Kind),
+		UUID: *ref.UUID,
+		Name: stringValue(ref.Name),
+	}
+	entity.URI = NutanixKindURI(entity.Kind, entity.UUID)
+
+	return entity
+}
+
+func appendUniqueRef(refs []*EntityRef, seen map[string]struct{}, ref *v3.Reference) []*EntityRef {
+	entity := newEntityRef(ref)
+	if entity == nil {
+		return refs
+	}
+
+	key := entity.Kind + "/" + entity.UUID
+	if _, ok := seen[key]; ok {
+		return refs
+	}
+	seen[key] = struct{}{}
+
+	return append(refs, entity)
+}
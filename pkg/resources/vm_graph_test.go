@@ -0,0 +1,82 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func reference(kind, uuid, name string) *v3.Reference {
+	ref := &v3.Reference{Kind: &kind, UUID: &uuid}
+	if name != "" {
+		ref.Name = &name
+	}
+	return ref
+}
+
+func TestNewVMGraph(t *testing.T) {
+	name := "web-01"
+	vm := &v3.VMIntentResponse{
+		Metadata: &v3.Metadata{
+			ProjectReference: reference("project", "p1", ""),
+			OwnerReference:   reference("user", "u1", "admin"),
+			Categories:       map[string]string{"AppType": "web"},
+		},
+		Status: &v3.VMDefStatus{
+			Name:             &name,
+			ClusterReference: reference("cluster", "c1", ""),
+			Resources: &v3.VMResourcesDefStatus{
+				HostReference: reference("host", "h1", ""),
+				NicList: []*v3.VMNicOutputStatus{
+					{SubnetReference: reference("subnet", "s1", "")},
+					{SubnetReference: reference("subnet", "s1", "")},
+					{SubnetReference: reference("subnet", "s2", "")},
+				},
+				DiskList: []*v3.VMDisk{
+					{DataSourceReference: reference("image", "i1", "")},
+					{DataSourceReference: reference("vm_recovery_point", "r1", "")},
+					{VolumeGroupReference: reference("volume_group", "vg1", "")},
+				},
+			},
+		},
+	}
+
+	graph := newVMGraph("vm1", vm)
+	assert.Equal(t, "web-01", graph.VM.Name)
+	assert.Equal(t, "cluster://c1", graph.Cluster.URI)
+	assert.Equal(t, "h1", graph.Host.UUID)
+	assert.Len(t, graph.Subnets, 2)
+	require.Len(t, graph.Images, 1)
+	assert.Equal(t, "i1", graph.Images[0].UUID)
+	require.Len(t, graph.VolumeGroups, 1)
+	assert.Equal(t, "volume_group://vg1", graph.VolumeGroups[0].URI)
+	assert.Equal(t, "p1", graph.Project.UUID)
+	assert.Equal(t, "admin", graph.Owner.Name)
+	assert.Equal(t, "web", graph.Categories["AppType"])
+
+	lookups := 0
+	graph.resolve(context.Background(), func(_ context.Context, kind, uuid string) (string, error) {
+		lookups++
+		if kind == "user" {
+			return "", fmt.Errorf("forbidden")
+		}
+		return kind + "-" + uuid, nil
+	})
+
+	assert.Equal(t, 8, lookups)
+	assert.Equal(t, "cluster-c1", graph.Cluster.Name)
+	assert.Equal(t, "subnet-s2", graph.Subnets[1].Name)
+	assert.Equal(t, "admin", graph.Owner.Name)
+	assert.Equal(t, "forbidden", graph.Owner.Error)
+}
+
+func TestNewVMGraphWithoutStatus(t *testing.T) {
+	graph := newVMGraph("vm1", &v3.VMIntentResponse{})
+	assert.Nil(t, graph.Cluster)
+	assert.Empty(t, graph.Subnets)
+	assert.Equal(t, "vm://vm1", graph.VM.URI)
+}
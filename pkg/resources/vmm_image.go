@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// VmmImage defines the VmmImage resource template
+func VmmImage() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		string(ResourceURIPrefix(ResourceTypeVmmImage))+"{extId}{?fields,detail,jq,profile}",
+		string(ResourceTypeVmmImage),
+		mcp.WithTemplateDescription("Image resource (v4 vmm API), addressed by extId"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// VmmImageHandler implements the handler for the VmmImage resource
+func VmmImageHandler() server.ResourceTemplateHandlerFunc {
+	return CreateResourceHandler(ResourceTypeVmmImage, GetVmmImage)
+}
+
+// GetVmmImage gets a VmmImage by its extId
+func GetVmmImage(ctx context.Context, client *client.NutanixClient, extID string) (interface{}, error) {
+	v4Client, err := client.V4()
+	if err != nil {
+		return nil, err
+	}
+
+	return v4Client.ImagesApiInstance.GetImageById(&extID)
+}
@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// VmmVM defines the VmmVM resource template
+func VmmVM() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		string(ResourceURIPrefix(ResourceTypeVmmVM))+"{extId}{?fields,detail,jq,profile}",
+		string(ResourceTypeVmmVM),
+		mcp.WithTemplateDescription("Virtual Machine resource (v4 vmm API), addressed by extId"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// VmmVMHandler implements the handler for the VmmVM resource
+func VmmVMHandler() server.ResourceTemplateHandlerFunc {
+	return CreateResourceHandler(ResourceTypeVmmVM, GetVmmVM)
+}
+
+// GetVmmVM gets a VmmVM by its extId
+func GetVmmVM(ctx context.Context, client *client.NutanixClient, extID string) (interface{}, error) {
+	v4Client, err := client.V4()
+	if err != nil {
+		return nil, err
+	}
+
+	return v4Client.VmApiInstance.GetVmById(&extID)
+}
@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// VolumeGroup defines the VolumeGroup resource template
+func VolumeGroup() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		string(ResourceURIPrefix(ResourceTypeVolumeGroup))+"{uuid}{?fields,detail,jq,profile}",
+		string(ResourceTypeVolumeGroup),
+		mcp.WithTemplateDescription("Volume Group resource"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// VolumeGroupHandler implements the handler for the VolumeGroup resource
+func VolumeGroupHandler() server.ResourceTemplateHandlerFunc {
+	return CreateResourceHandler(ResourceTypeVolumeGroup, GetVolumeGroup)
+}
+
+// GetVolumeGroup gets a VolumeGroup by its UUID
+func GetVolumeGroup(ctx context.Context, client *client.NutanixClient, uuid string) (interface{}, error) {
+	v3Client, err := client.V3()
+	if err != nil {
+		return nil, err
+	}
+
+	return v3Client.GetVolumeGroup(ctx, uuid)
+}
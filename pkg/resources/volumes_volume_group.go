@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// VolumesVolumeGroup defines the VolumesVolumeGroup resource template
+func VolumesVolumeGroup() mcp.ResourceTemplate {
+	return mcp.NewResourceTemplate(
+		string(ResourceURIPrefix(ResourceTypeVolumesVolumeGroup))+"{extId}{?fields,detail,jq,profile}",
+		string(ResourceTypeVolumesVolumeGroup),
+		mcp.WithTemplateDescription("Volume Group resource (v4 volumes API), addressed by extId"),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+}
+
+// VolumesVolumeGroupHandler implements the handler for the VolumesVolumeGroup resource
+func VolumesVolumeGroupHandler() server.ResourceTemplateHandlerFunc {
+	return CreateResourceHandler(ResourceTypeVolumesVolumeGroup, GetVolumesVolumeGroup)
+}
+
+// GetVolumesVolumeGroup gets a VolumesVolumeGroup by its extId
+func GetVolumesVolumeGroup(ctx context.Context, client *client.NutanixClient, extID string) (interface{}, error) {
+	v4Client, err := client.V4()
+	if err != nil {
+		return nil, err
+	}
+
+	return v4Client.VolumeGroupsApiInstance.GetVolumeGroupById(&extID)
+}
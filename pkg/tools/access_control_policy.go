@@ -0,0 +1,93 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// AccessControlPolicyList defines the AccessControlPolicy list tool
+func AccessControlPolicyList() mcp.Tool {
+	opts := []mcp.ToolOption{
+		mcp.WithDescription("List access_control_policy resources one page at a time"),
+		mcp.WithString("filter",
+			mcp.Description("Optional Prism FIQL filter, e.g. name==prod.* (';' is AND, ',' is OR)"),
+		),
+		mcp.WithString("fields",
+			mcp.Description("Optional comma-separated entity field paths to return, e.g. spec.name,metadata.uuid"),
+		),
+		withDetailArgument(),
+		withJQArgument(),
+		withFormatArgument(),
+		withMaxBytesArgument(),
+		withProfileArgument(),
+	}
+
+	return mcp.NewTool("access_control_policy_list", append(opts, withPagingArguments()...)...)
+}
+
+// AccessControlPolicyListHandler implements the handler for the AccessControlPolicy list tool
+func AccessControlPolicyListHandler() server.ToolHandlerFunc {
+	return CreateListToolHandler(
+		resources.ResourceTypeAccessControlPolicy,
+		// Define the ListResourceFunc implementation
+		func(ctx context.Context, client *client.NutanixClient, opts ListOptions) (interface{}, error) {
+			v3Client, err := client.V3()
+			if err != nil {
+				return nil, err
+			}
+
+			// Fetch a single page using the paging and sorting options
+			return v3Client.ListAccessControlPolicy(ctx, opts.DSMetadata("access_control_policy"))
+		},
+	)
+}
+
+// AccessControlPolicyCount defines the AccessControlPolicy count tool
+func AccessControlPolicyCount() mcp.Tool {
+	return mcp.NewTool("access_control_policy_count",
+		mcp.WithDescription("Count access_control_policy resources"),
+		mcp.WithString("filter",
+			mcp.Description("Optional Prism FIQL filter, e.g. name==prod.* (';' is AND, ',' is OR)"),
+		),
+		withProfileArgument(),
+	)
+}
+
+// AccessControlPolicyCountHandler implements the handler for the AccessControlPolicy count tool
+func AccessControlPolicyCountHandler() server.ToolHandlerFunc {
+	return CreateCountToolHandler(
+		resources.ResourceTypeAccessControlPolicy,
+		// Define the ListResourceFunc implementation
+		func(ctx context.Context, client *client.NutanixClient, opts ListOptions) (interface{}, error) {
+			v3Client, err := client.V3()
+			if err != nil {
+				return nil, err
+			}
+
+			// Fetch a single entity; the server-side total covers the rest
+			opts.Length = 1
+			resp, err := v3Client.ListAccessControlPolicy(ctx, opts.DSMetadata("access_control_policy"))
+			if err != nil {
+				return nil, err
+			}
+			count, ok := totalMatches(resp)
+			if !ok {
+				return nil, fmt.Errorf("response has no total_matches")
+			}
+
+			res := map[string]interface{}{
+				"resource_type": "AccessControlPolicy",
+				"count":         count,
+				"metadata":      resp.Metadata,
+			}
+
+			return res, nil
+		},
+	)
+}
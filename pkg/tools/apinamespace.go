@@ -3,7 +3,6 @@ package tools
 import (
 	"context"
 
-	"github.com/thunderboltsid/mcp-nutanix/internal/client"
 	"github.com/thunderboltsid/mcp-nutanix/internal/json"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -14,28 +13,33 @@ import (
 func ApiNamespacesList() mcp.Tool {
 	return mcp.NewTool("api_namespaces_list",
 		mcp.WithDescription("List available API namespaces and their routes in Prism Central"),
+		withMaxBytesArgument(),
+		withProfileArgument(),
 	)
 }
 
 // ApiNamespacesListHandler implements the handler for the API namespaces list tool
 func ApiNamespacesListHandler() server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Get the Prism client
-		prismClient := client.GetPrismClient()
+		// Get the Prism client of the selected profile
+		prismClient, err := prismClientFor(request)
+		if err != nil {
+			return nil, err
+		}
 
 		// Call the Actuator API to get version routes
-		response, err := prismClient.V4().ActuatorApiInstance.GetVersionRoutes(ctx)
+		response, err := prismClient.VersionRoutes(ctx)
 		if err != nil {
 			return nil, err
 		}
 
-		// Convert to JSON using regular JSON encoder
-		cjson := json.RegularJSONEncoder(response)
+		// Convert to JSON, masking secrets without stripping any route
+		cjson := json.StrippedJSONEncoder(response, nil, nil)
 		jsonBytes, err := cjson.MarshalJSON()
 		if err != nil {
 			return nil, err
 		}
 
-		return mcp.NewToolResultText(string(jsonBytes)), nil
+		return newJSONResult(request, jsonBytes, nil, redactionContents(cjson.Redactions)...)
 	}
 }
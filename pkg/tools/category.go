@@ -0,0 +1,93 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// CategoryList defines the Category list tool
+func CategoryList() mcp.Tool {
+	opts := []mcp.ToolOption{
+		mcp.WithDescription("List category resources one page at a time"),
+		mcp.WithString("filter",
+			mcp.Description("Optional Prism FIQL filter, e.g. name==prod.* (';' is AND, ',' is OR)"),
+		),
+		mcp.WithString("fields",
+			mcp.Description("Optional comma-separated entity field paths to return, e.g. spec.name,metadata.uuid"),
+		),
+		withDetailArgument(),
+		withJQArgument(),
+		withFormatArgument(),
+		withMaxBytesArgument(),
+		withProfileArgument(),
+	}
+
+	return mcp.NewTool("category_list", append(opts, withPagingArguments()...)...)
+}
+
+// CategoryListHandler implements the handler for the Category list tool
+func CategoryListHandler() server.ToolHandlerFunc {
+	return CreateListToolHandler(
+		resources.ResourceTypeCategory,
+		// Define the ListResourceFunc implementation
+		func(ctx context.Context, client *client.NutanixClient, opts ListOptions) (interface{}, error) {
+			v3Client, err := client.V3()
+			if err != nil {
+				return nil, err
+			}
+
+			// Fetch a single page using the paging and sorting options
+			return v3Client.ListCategories(ctx, opts.CategoryListMetadata("category"))
+		},
+	)
+}
+
+// CategoryCount defines the Category count tool
+func CategoryCount() mcp.Tool {
+	return mcp.NewTool("category_count",
+		mcp.WithDescription("Count category resources"),
+		mcp.WithString("filter",
+			mcp.Description("Optional Prism FIQL filter, e.g. name==prod.* (';' is AND, ',' is OR)"),
+		),
+		withProfileArgument(),
+	)
+}
+
+// CategoryCountHandler implements the handler for the Category count tool
+func CategoryCountHandler() server.ToolHandlerFunc {
+	return CreateCountToolHandler(
+		resources.ResourceTypeCategory,
+		// Define the ListResourceFunc implementation
+		func(ctx context.Context, client *client.NutanixClient, opts ListOptions) (interface{}, error) {
+			v3Client, err := client.V3()
+			if err != nil {
+				return nil, err
+			}
+
+			// Fetch a single entity; the server-side total covers the rest
+			opts.Length = 1
+			resp, err := v3Client.ListCategories(ctx, opts.CategoryListMetadata("category"))
+			if err != nil {
+				return nil, err
+			}
+			count, ok := totalMatches(resp)
+			if !ok {
+				return nil, fmt.Errorf("response has no total_matches")
+			}
+
+			res := map[string]interface{}{
+				"resource_type": "Category",
+				"count":         count,
+				"metadata":      resp.Metadata,
+			}
+
+			return res, nil
+		},
+	)
+}
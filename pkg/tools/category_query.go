@@ -0,0 +1,243 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/json"
+	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/nutanix-cloud-native/prism-go-client/utils"
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+)
+
+const (
+	categoryMatchAll = "all"
+	categoryMatchAny = "any"
+
+	defaultCategoryQueryLimit = 50
+	maxCategoryQueryLimit     = 500
+)
+
+// defaultCategoryQueryKinds are the entity kinds queried when no kinds are given
+var defaultCategoryQueryKinds = []string{"vm", "host", "cluster", "subnet", "image", "volume_group"}
+
+// CategoryEntity is an entity tagged with the queried categories
+type CategoryEntity struct {
+	UUID       string            `json:"uuid"`
+	Name       string            `json:"name,omitempty"`
+	URI        string            `json:"uri,omitempty"`
+	Categories map[string]string `json:"categories,omitempty"`
+}
+
+// CategoryQueryGroup holds the entities of one kind matching a category query
+type CategoryQueryGroup struct {
+	Total    int64            `json:"total"`
+	Returned int              `json:"returned"`
+	Entities []CategoryEntity `json:"entities"`
+}
+
+// CategoryQuery defines the category_query tool
+func CategoryQuery() mcp.Tool {
+	return mcp.NewTool("category_query",
+		mcp.WithDescription("List entities (VMs, hosts, clusters, subnets, images, volume groups, ...) tagged with category key=value pairs, grouped by entity kind. "+
+			"Each kind reports the total number of matches and how many were returned; when total exceeds offset+returned, call again with a larger offset for the rest"),
+		mcp.WithString("categories",
+			mcp.Required(),
+			mcp.Description("Comma-separated key=value pairs, e.g. Environment=Production,AppType=web. Values given for the same key are ORed"),
+		),
+		mcp.WithString("match",
+			mcp.Description("all (default) requires every category key to match, any requires at least one"),
+			mcp.Enum(categoryMatchAll, categoryMatchAny),
+		),
+		mcp.WithString("kinds",
+			mcp.Description("Optional comma-separated entity kinds to query, defaults to "+strings.Join(defaultCategoryQueryKinds, ",")),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(fmt.Sprintf("Maximum number of entities returned per kind (default %d, max %d)", defaultCategoryQueryLimit, maxCategoryQueryLimit)),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Number of entities of each kind to skip, for fetching the matches past the first page (default 0)"),
+		),
+		withMaxBytesArgument(),
+		withProfileArgument(),
+	)
+}
+
+// CategoryQueryHandler implements the handler for the category_query tool
+func CategoryQueryHandler() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get the Prism client of the selected profile
+		prismClient, err := prismClientFor(request)
+		if err != nil {
+			return nil, err
+		}
+
+		input, err := parseCategoryQuery(request)
+		if err != nil {
+			return nil, err
+		}
+
+		v3Client, err := prismClient.V3()
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := v3Client.GetCategoryQuery(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query categories: %w", err)
+		}
+
+		res := map[string]interface{}{
+			"categories": input.CategoryFilter.Params,
+			"match":      categoryMatchFromFilterType(*input.CategoryFilter.Type),
+			"offset":     *input.GroupMemberOffset,
+			"results":    groupCategoryQueryResults(resp, prismClient.Profile()),
+		}
+
+		// Convert to JSON
+		cjson := json.CustomJSONEncoder(res)
+		jsonBytes, err := cjson.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal category query results: %w", err)
+		}
+
+		return newJSONResult(request, jsonBytes, nil, redactionContents(cjson.Redactions)...)
+	}
+}
+
+// parseCategoryQuery builds the v3 category query from the tool arguments
+func parseCategoryQuery(request mcp.CallToolRequest) (*v3.CategoryQueryInput, error) {
+	params, err := parseCategoryPairs(stringArgument(request, "categories"))
+	if err != nil {
+		return nil, err
+	}
+
+	filterType := "CATEGORIES_MATCH_ALL"
+	switch match := stringArgument(request, "match"); match {
+	case "", categoryMatchAll:
+	case categoryMatchAny:
+		filterType = "CATEGORIES_MATCH_ANY"
+	default:
+		return nil, fmt.Errorf("match must be %s or %s, got %q", categoryMatchAll, categoryMatchAny, match)
+	}
+
+	kinds := splitList(stringArgument(request, "kinds"))
+	if len(kinds) == 0 {
+		kinds = append([]string(nil), defaultCategoryQueryKinds...)
+	}
+	kindList := make([]*string, 0, len(kinds))
+	for i := range kinds {
+		kindList = append(kindList, &kinds[i])
+	}
+
+	limit, err := int64Argument(request, "limit", defaultCategoryQueryLimit)
+	if err != nil {
+		return nil, err
+	}
+	if limit < 1 || limit > maxCategoryQueryLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxCategoryQueryLimit)
+	}
+
+	offset, err := int64Argument(request, "offset", 0)
+	if err != nil {
+		return nil, err
+	}
+	if offset < 0 {
+		return nil, fmt.Errorf("offset must not be negative")
+	}
+
+	usageType := "APPLIED_TO"
+
+	return &v3.CategoryQueryInput{
+		CategoryFilter: &v3.CategoryFilter{
+			KindList: kindList,
+			Params:   params,
+			Type:     &filterType,
+		},
+		GroupMemberCount:  &limit,
+		GroupMemberOffset: &offset,
+		UsageType:         &usageType,
+	}, nil
+}
+
+// parseCategoryPairs parses comma-separated key=value pairs, grouping the values of repeated keys.
+// Values may contain spaces, e.g. AppType=Web Server.
+func parseCategoryPairs(raw string) (map[string][]string, error) {
+	params := map[string][]string{}
+	for _, pair := range strings.Split(raw, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, value, found := strings.Cut(pair, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !found || key == "" || value == "" {
+			return nil, fmt.Errorf("invalid category %q, expected key=value", pair)
+		}
+		params[key] = append(params[key], value)
+	}
+
+	if len(params) == 0 {
+		return nil, fmt.Errorf("categories must contain at least one key=value pair, e.g. Environment=Production")
+	}
+
+	return params, nil
+}
+
+// groupCategoryQueryResults groups the entities of a category query response by kind,
+// with URIs reading the entities from the given connection profile
+func groupCategoryQueryResults(resp *v3.CategoryQueryResponse, profile string) map[string]*CategoryQueryGroup {
+	groups := map[string]*CategoryQueryGroup{}
+	for _, result := range resp.Results {
+		if result == nil || result.Kind == nil {
+			continue
+		}
+
+		group, ok := groups[*result.Kind]
+		if !ok {
+			group = &CategoryQueryGroup{Entities: []CategoryEntity{}}
+			groups[*result.Kind] = group
+		}
+
+		for _, ref := range result.EntityAnyReferenceList {
+			if ref == nil || ref.UUID == nil {
+				continue
+			}
+			group.Entities = append(group.Entities, CategoryEntity{
+				UUID:       *ref.UUID,
+				Name:       utils.StringValue(ref.Name),
+				URI:        resources.ProfileURI(resources.NutanixKindURI(*result.Kind, *ref.UUID), profile),
+				Categories: ref.Categories,
+			})
+		}
+
+		if result.FilteredEntityCount != nil {
+			group.Total += *result.FilteredEntityCount
+		} else if result.TotalEntityCount != nil {
+			group.Total += *result.TotalEntityCount
+		}
+	}
+
+	for _, group := range groups {
+		sort.Slice(group.Entities, func(i, j int) bool {
+			return group.Entities[i].Name < group.Entities[j].Name
+		})
+		group.Returned = len(group.Entities)
+		if group.Total < int64(group.Returned) {
+			group.Total = int64(group.Returned)
+		}
+	}
+
+	return groups
+}
+
+func categoryMatchFromFilterType(filterType string) string {
+	if filterType == "CATEGORIES_MATCH_ANY" {
+		return categoryMatchAny
+	}
+	return categoryMatchAll
+}
@@ -0,0 +1,85 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCategoryPairs(t *testing.T) {
+	params, err := parseCategoryPairs("Environment=Production, AppType=web,AppType=Web Server,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"Environment": {"Production"},
+		"AppType":     {"web", "Web Server"},
+	}, params)
+
+	for _, raw := range []string{"", " , ", "Environment", "=Production", "Environment="} {
+		_, err := parseCategoryPairs(raw)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestParseCategoryQuery(t *testing.T) {
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]interface{}{
+		"categories": "Environment=Production",
+		"match":      "any",
+		"kinds":      "vm, subnet",
+		"limit":      float64(10),
+		"offset":     float64(500),
+	}
+
+	input, err := parseCategoryQuery(request)
+	require.NoError(t, err)
+	assert.Equal(t, "CATEGORIES_MATCH_ANY", *input.CategoryFilter.Type)
+	require.Len(t, input.CategoryFilter.KindList, 2)
+	assert.Equal(t, "subnet", *input.CategoryFilter.KindList[1])
+	assert.Equal(t, int64(10), *input.GroupMemberCount)
+	assert.Equal(t, int64(500), *input.GroupMemberOffset)
+	assert.Equal(t, "APPLIED_TO", *input.UsageType)
+
+	request.Params.Arguments = map[string]interface{}{"categories": "Environment=Production"}
+	input, err = parseCategoryQuery(request)
+	require.NoError(t, err)
+	assert.Equal(t, "CATEGORIES_MATCH_ALL", *input.CategoryFilter.Type)
+	assert.Len(t, input.CategoryFilter.KindList, len(defaultCategoryQueryKinds))
+	assert.Equal(t, int64(0), *input.GroupMemberOffset)
+
+	request.Params.Arguments = map[string]interface{}{"categories": "Environment=Production", "offset": float64(-1)}
+	_, err = parseCategoryQuery(request)
+	assert.Error(t, err)
+
+	request.Params.Arguments = map[string]interface{}{"categories": "Environment=Production", "match": "some"}
+	_, err = parseCategoryQuery(request)
+	assert.Error(t, err)
+}
+
+func TestGroupCategoryQueryResults(t *testing.T) {
+	str := func(s string) *string { return &s }
+	count := int64(3)
+
+	groups := groupCategoryQueryResults(&v3.CategoryQueryResponse{
+		Results: []*v3.CategoryQueryResponseResults{
+			{
+				Kind:                str("vm"),
+				FilteredEntityCount: &count,
+				EntityAnyReferenceList: []*v3.EntityReference{
+					{UUID: str("2"), Name: str("web-02")},
+					{UUID: str("1"), Name: str("web-01")},
+				},
+			},
+			{Kind: str("subnet")},
+		},
+	}, "lab")
+
+	require.Contains(t, groups, "vm")
+	assert.Equal(t, int64(3), groups["vm"].Total)
+	assert.Equal(t, 2, groups["vm"].Returned)
+	assert.Equal(t, "web-01", groups["vm"].Entities[0].Name)
+	assert.Equal(t, "vm://1?profile=lab", groups["vm"].Entities[0].URI)
+	assert.Empty(t, groups["subnet"].Entities)
+}
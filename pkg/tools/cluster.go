@@ -0,0 +1,93 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ClusterList defines the Cluster list tool
+func ClusterList() mcp.Tool {
+	opts := []mcp.ToolOption{
+		mcp.WithDescription("List cluster resources one page at a time"),
+		mcp.WithString("filter",
+			mcp.Description("Optional Prism FIQL filter, e.g. name==prod.* (';' is AND, ',' is OR)"),
+		),
+		mcp.WithString("fields",
+			mcp.Description("Optional comma-separated entity field paths to return, e.g. spec.name,metadata.uuid"),
+		),
+		withDetailArgument(),
+		withJQArgument(),
+		withFormatArgument(),
+		withMaxBytesArgument(),
+		withProfileArgument(),
+	}
+
+	return mcp.NewTool("cluster_list", append(opts, withPagingArguments()...)...)
+}
+
+// ClusterListHandler implements the handler for the Cluster list tool
+func ClusterListHandler() server.ToolHandlerFunc {
+	return CreateListToolHandler(
+		resources.ResourceTypeCluster,
+		// Define the ListResourceFunc implementation
+		func(ctx context.Context, client *client.NutanixClient, opts ListOptions) (interface{}, error) {
+			v3Client, err := client.V3()
+			if err != nil {
+				return nil, err
+			}
+
+			// Fetch a single page using the paging and sorting options
+			return v3Client.ListCluster(ctx, opts.DSMetadata("cluster"))
+		},
+	)
+}
+
+// ClusterCount defines the Cluster count tool
+func ClusterCount() mcp.Tool {
+	return mcp.NewTool("cluster_count",
+		mcp.WithDescription("Count cluster resources"),
+		mcp.WithString("filter",
+			mcp.Description("Optional Prism FIQL filter, e.g. name==prod.* (';' is AND, ',' is OR)"),
+		),
+		withProfileArgument(),
+	)
+}
+
+// ClusterCountHandler implements the handler for the Cluster count tool
+func ClusterCountHandler() server.ToolHandlerFunc {
+	return CreateCountToolHandler(
+		resources.ResourceTypeCluster,
+		// Define the ListResourceFunc implementation
+		func(ctx context.Context, client *client.NutanixClient, opts ListOptions) (interface{}, error) {
+			v3Client, err := client.V3()
+			if err != nil {
+				return nil, err
+			}
+
+			// Fetch a single entity; the server-side total covers the rest
+			opts.Length = 1
+			resp, err := v3Client.ListCluster(ctx, opts.DSMetadata("cluster"))
+			if err != nil {
+				return nil, err
+			}
+			count, ok := totalMatches(resp)
+			if !ok {
+				return nil, fmt.Errorf("response has no total_matches")
+			}
+
+			res := map[string]interface{}{
+				"resource_type": "Cluster",
+				"count":         count,
+				"metadata":      resp.Metadata,
+			}
+
+			return res, nil
+		},
+	)
+}
@@ -0,0 +1,59 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// listClustermgmtCluster fetches a single page of ClustermgmtCluster resources from the v4 API
+func listClustermgmtCluster(ctx context.Context, client *client.NutanixClient, opts V4ListOptions) (interface{}, error) {
+	v4Client, err := client.V4()
+	if err != nil {
+		return nil, err
+	}
+
+	return v4Client.ClustersApiInstance.ListClusters(opts.Page, opts.Limit, opts.Filter, opts.OrderBy, nil, opts.Select)
+}
+
+// ClustermgmtClusterList defines the ClustermgmtCluster list tool
+func ClustermgmtClusterList() mcp.Tool {
+	opts := []mcp.ToolOption{
+		mcp.WithDescription("List clustermgmt_cluster resources one page at a time using the v4 API"),
+		mcp.WithString("filter",
+			mcp.Description("Optional OData $filter expression, e.g. startswith(name, 'prod')"),
+		),
+		withDetailArgument(),
+		withJQArgument(),
+		withFormatArgument(),
+		withMaxBytesArgument(),
+		withProfileArgument(),
+	}
+
+	return mcp.NewTool("clustermgmt_cluster_list", append(opts, withODataArguments()...)...)
+}
+
+// ClustermgmtClusterListHandler implements the handler for the ClustermgmtCluster list tool
+func ClustermgmtClusterListHandler() server.ToolHandlerFunc {
+	return CreateV4ListToolHandler(resources.ResourceTypeClustermgmtCluster, listClustermgmtCluster)
+}
+
+// ClustermgmtClusterCount defines the ClustermgmtCluster count tool
+func ClustermgmtClusterCount() mcp.Tool {
+	return mcp.NewTool("clustermgmt_cluster_count",
+		mcp.WithDescription("Count clustermgmt_cluster resources using the v4 API"),
+		mcp.WithString("filter",
+			mcp.Description("Optional OData $filter expression, e.g. startswith(name, 'prod')"),
+		),
+		withProfileArgument(),
+	)
+}
+
+// ClustermgmtClusterCountHandler implements the handler for the ClustermgmtCluster count tool
+func ClustermgmtClusterCountHandler() server.ToolHandlerFunc {
+	return CreateV4CountToolHandler(resources.ResourceTypeClustermgmtCluster, listClustermgmtCluster)
+}
@@ -12,7 +12,7 @@ import (
 )
 
 // ListResourceFunc defines a function that handles listing a resource type
-type ListResourceFunc func(ctx context.Context, client *client.NutanixClient, filter string) (interface{}, error)
+type ListResourceFunc func(ctx context.Context, client *client.NutanixClient, opts ListOptions) (interface{}, error)
 
 // CreateListToolHandler creates a generic tool handler for listing resources
 func CreateListToolHandler(
@@ -20,29 +20,92 @@ func CreateListToolHandler(
 	listFunc ListResourceFunc,
 ) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Get the Prism client
-		prismClient := client.GetPrismClient()
-		if prismClient == nil {
-			return nil, fmt.Errorf("prism client not initialized, please set credentials first")
+		// Get the Prism client of the selected profile
+		prismClient, err := prismClientFor(request)
+		if err != nil {
+			return nil, err
 		}
 
-		// Get filter if provided (for LLM reference only)
-		//filter, _ := request.Params.Arguments["filter"].(string)
+		// Get the filter, paging and sorting options if provided
+		opts, err := parseListOptions(resourceType, prismClient.Profile(), request)
+		if err != nil {
+			return nil, err
+		}
 
-		// List all resources
-		resp, err := listFunc(ctx, prismClient, "")
+		// Get the entity fields to project if provided
+		fields, err := parseFieldsArgument(request)
+		if err != nil {
+			return nil, err
+		}
+
+		// Get the paths to strip for the requested detail level
+		stripPaths, err := parseDetailArgument(resourceType, request)
+		if err != nil {
+			return nil, err
+		}
+
+		// Get the view, summaries replace the raw entities with flattened records
+		view, err := parseViewArgument(resourceType, request)
+		if err != nil {
+			return nil, err
+		}
+
+		// Compile the jq expression if provided, reporting invalid expressions as tool errors
+		query, err := parseJQArgument(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get the output format, tables use the requested fields as columns
+		format, err := parseFormatArgument(request)
+		if err != nil {
+			return nil, err
+		}
+
+		// List a single page of resources
+		resp, err := listFunc(ctx, prismClient, opts)
 		if err != nil {
 			return nil, fmt.Errorf("failed to list %s: %w", resourceType, err)
 		}
 
-		// Convert to JSON
-		cjson := json.CustomJSONEncoder(resp)
+		// Describe the page so the caller can request the next one
+		page, err := newPageInfo(resourceType, prismClient.Profile(), opts, resp)
+		if err != nil {
+			return nil, err
+		}
+
+		// Convert to the requested view
+		entities, err := resources.ApplyView(resourceType, view, resp)
+		if err != nil {
+			return nil, err
+		}
+
+		// Convert to JSON, linking every entity to its resource, e.g. vm://{uuid}
+		cjson := json.StrippedJSONEncoder(entities, stripPaths, fields)
+		links := newEntityLinks(resourceType, prismClient.Profile())
+		cjson.EntityURI = links.EntityURI
 		jsonBytes, err := cjson.MarshalJSON()
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal %s: %w", resourceType, err)
 		}
 
-		return mcp.NewToolResultText(string(jsonBytes)), nil
+		// Run the jq expression if provided, reporting failures as tool errors
+		jsonBytes, err = runJQQuery(ctx, query, jsonBytes)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		pageBytes, err := json.RegularJSONEncoder(page).MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s page info: %w", resourceType, err)
+		}
+
+		extra := append([]mcp.Content{mcp.NewTextContent(string(pageBytes))}, redactionContents(cjson.Redactions)...)
+
+		// Render and truncate to the response budget, keeping the page info and embedding a resource
+		// for every returned entity, e.g. vm://{uuid}
+		columns := splitList(stringArgument(request, "fields"))
+		return newFormattedResult(request, format, jsonBytes, columns, links, extra...)
 	}
 }
 
@@ -52,17 +115,20 @@ func CreateCountToolHandler(
 	countFunc ListResourceFunc,
 ) server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Get the Prism client
-		prismClient := client.GetPrismClient()
-		if prismClient == nil {
-			return nil, fmt.Errorf("prism client not initialized, please set credentials first")
+		// Get the Prism client of the selected profile
+		prismClient, err := prismClientFor(request)
+		if err != nil {
+			return nil, err
 		}
 
-		// Get filter if provided (for LLM reference only)
-		//filter, _ := request.Params.Arguments["filter"].(string)
+		// Get the FIQL filter if provided
+		filter, err := parseFilterArgument(request)
+		if err != nil {
+			return nil, err
+		}
 
 		// List all resources
-		resp, err := countFunc(ctx, prismClient, "")
+		resp, err := countFunc(ctx, prismClient, ListOptions{Filter: filter})
 		if err != nil {
 			return nil, fmt.Errorf("failed to list %s: %w", resourceType, err)
 		}
@@ -77,3 +143,23 @@ func CreateCountToolHandler(
 		return mcp.NewToolResultText(string(jsonBytes)), nil
 	}
 }
+
+// parseFieldsArgument reads the optional fields argument of a list tool and
+// converts the entity-relative paths into paths over the list response
+func parseFieldsArgument(request mcp.CallToolRequest) ([]string, error) {
+	fields, err := json.ParseFieldList(stringArgument(request, "fields"))
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	// Always keep the list metadata alongside the projected entities
+	paths := []string{"metadata"}
+	for _, field := range fields {
+		paths = append(paths, "entities[]."+field)
+	}
+
+	return paths, nil
+}
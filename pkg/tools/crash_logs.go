@@ -23,20 +23,19 @@ func CrashLogsCritical() mcp.Tool {
 		mcp.WithString("lines",
 			mcp.Description("Optional number of lines per file to return (default 50, max 500)"),
 		),
+		withMaxBytesArgument(),
 	)
 }
 
 // CrashLogsCriticalHandler implements the handler for the crash_logs_critical tool.
 func CrashLogsCriticalHandler() server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		_ = ctx
-
 		lines, err := parseCrashLogLines(request)
 		if err != nil {
 			return nil, err
 		}
 
-		cfg, err := getSSHConfig()
+		cfg, err := getSSHConfig(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -47,7 +46,7 @@ func CrashLogsCriticalHandler() server.ToolHandlerFunc {
 			return nil, err
 		}
 
-		return mcp.NewToolResultText(string(output)), nil
+		return newTextResult(request, string(output), nil)
 	}
 }
 
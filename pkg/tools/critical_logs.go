@@ -15,20 +15,19 @@ func CriticalLogs() mcp.Tool {
 		mcp.WithString("lines",
 			mcp.Description("Optional number of lines to return per section (default 50, max 500)"),
 		),
+		withMaxBytesArgument(),
 	)
 }
 
 // CriticalLogsHandler implements the handler for the critical_logs tool.
 func CriticalLogsHandler() server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		_ = ctx
-
 		lines, err := parseKernelLogLines(request)
 		if err != nil {
 			return nil, err
 		}
 
-		cfg, err := getSSHConfig()
+		cfg, err := getSSHConfig(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -47,7 +46,7 @@ func CriticalLogsHandler() server.ToolHandlerFunc {
 			return nil, err
 		}
 
-		return mcp.NewToolResultText(string(output)), nil
+		return newTextResult(request, string(output), nil)
 	}
 }
 
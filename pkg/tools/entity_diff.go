@@ -0,0 +1,134 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/json"
+	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// entityDiffSnapshot names the right side of a diff against a snapshot
+const entityDiffSnapshot = "snapshot"
+
+// EntityDiffResult holds the differences between two entities of the same type
+type EntityDiffResult struct {
+	Left        string            `json:"left"`
+	Right       string            `json:"right"`
+	Ignored     []string          `json:"ignored"`
+	Differences int               `json:"differences"`
+	Changes     []json.DiffChange `json:"changes"`
+}
+
+// EntityDiff defines the entity_diff tool
+func EntityDiff() mcp.Tool {
+	return mcp.NewTool("entity_diff",
+		mcp.WithDescription("Compare the configuration of two entities of the same type, e.g. two VMs, or of an entity and a previously captured snapshot. Returns the differing spec fields, ignoring status and volatile metadata such as spec_version and timestamps"),
+		mcp.WithString("resource_type",
+			mcp.Required(),
+			mcp.Description("Type of the entities, e.g. vm or vmm_vm"),
+			mcp.Enum(diffResourceTypes()...),
+		),
+		mcp.WithString("uuid",
+			mcp.Required(),
+			mcp.Description("UUID (or v4 extId) of the entity to compare"),
+		),
+		mcp.WithString("other_uuid",
+			mcp.Description("UUID (or v4 extId) of the entity to compare against; either other_uuid or snapshot is required"),
+		),
+		mcp.WithString("snapshot",
+			mcp.Description("JSON of a previously captured entity to compare against, e.g. the contents of vm://{uuid}"),
+		),
+		mcp.WithString("ignore",
+			mcp.Description("Optional comma-separated paths to ignore in addition to the volatile ones, e.g. ..uuid,spec.resources.nic_list[].mac_address"),
+		),
+		withProfileArgument(),
+	)
+}
+
+// EntityDiffHandler implements the handler for the entity_diff tool
+func EntityDiffHandler() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get the Prism client of the selected profile
+		prismClient, err := prismClientFor(request)
+		if err != nil {
+			return nil, err
+		}
+
+		resourceType := resources.ResourceType(stringArgument(request, "resource_type"))
+		get, ok := resources.Getters[resourceType]
+		if !ok {
+			return nil, fmt.Errorf("unknown resource_type %q", resourceType)
+		}
+
+		uuid := stringArgument(request, "uuid")
+		if uuid == "" {
+			return nil, fmt.Errorf("uuid is required")
+		}
+		otherUUID := stringArgument(request, "other_uuid")
+		snapshot := stringArgument(request, "snapshot")
+		if (otherUUID == "") == (snapshot == "") {
+			return nil, fmt.Errorf("either other_uuid or snapshot is required")
+		}
+
+		// Get the paths to ignore in addition to the volatile ones
+		extra, err := json.ParseFieldList(stringArgument(request, "ignore"))
+		if err != nil {
+			return nil, err
+		}
+		ignore := append(append([]string{}, json.VolatilePaths...), extra...)
+
+		left, err := get(ctx, prismClient, uuid)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s %s: %w", resourceType, uuid, err)
+		}
+
+		result := &EntityDiffResult{
+			Left:    resources.ProfileURI(resources.NutanixURI(resourceType, uuid), prismClient.Profile()),
+			Ignored: ignore,
+		}
+
+		var right interface{}
+		if otherUUID != "" {
+			if right, err = get(ctx, prismClient, otherUUID); err != nil {
+				return nil, fmt.Errorf("failed to get %s %s: %w", resourceType, otherUUID, err)
+			}
+			result.Right = resources.ProfileURI(resources.NutanixURI(resourceType, otherUUID), prismClient.Profile())
+		} else {
+			if right, err = json.ParseDocument([]byte(snapshot)); err != nil {
+				return nil, fmt.Errorf("invalid snapshot: %w", err)
+			}
+			result.Right = entityDiffSnapshot
+		}
+
+		changes, redactions, err := json.Diff(left, right, ignore)
+		if err != nil {
+			return nil, fmt.Errorf("failed to diff %s: %w", resourceType, err)
+		}
+		result.Changes = changes
+		result.Differences = len(changes)
+
+		// Convert to JSON, secrets are redacted before diffing
+		jsonBytes, err := json.RegularJSONEncoder(result).MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s diff: %w", resourceType, err)
+		}
+
+		return newTextContentsResult(string(jsonBytes), nil, redactionContents(redactions)), nil
+	}
+}
+
+// diffResourceTypes returns the sorted resource types that can be diffed
+func diffResourceTypes() []string {
+	types := make([]string, 0, len(resources.Getters))
+	for resourceType := range resources.Getters {
+		types = append(types, string(resourceType))
+	}
+	sort.Strings(types)
+
+	return types
+}
@@ -0,0 +1,46 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"k8s.io/klog"
+)
+
+// WithErrorResults wraps a tool handler so that failures never crash the stdio server:
+// Prism client errors become MCP error results with a remediation hint, and panics become error results too
+func WithErrorResults(handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (result *mcp.CallToolResult, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				klog.Errorf("tool %s panicked: %v\n%s", request.Params.Name, r, debug.Stack())
+				result, err = mcp.NewToolResultError(fmt.Sprintf("tool %s failed unexpectedly: %v", request.Params.Name, r)), nil
+			}
+		}()
+
+		result, err = handler(ctx, request)
+		if err == nil {
+			return result, nil
+		}
+
+		return clientErrorResult(stringArgument(request, "profile"), err)
+	}
+}
+
+// clientErrorResult returns an error result with a remediation hint for Prism client errors,
+// and the error itself for any other error
+func clientErrorResult(profile string, err error) (*mcp.CallToolResult, error) {
+	var clientErr *client.Error
+	classified := client.Classify(profile, err)
+	if !errors.As(classified, &clientErr) {
+		return nil, err
+	}
+
+	return mcp.NewToolResultError(fmt.Sprintf("%s\n\n%s", classified, clientErr.Hint())), nil
+}
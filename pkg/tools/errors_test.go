@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithErrorResults(t *testing.T) {
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "vm_list"
+	request.Params.Arguments = map[string]interface{}{"profile": "errors-missing"}
+
+	// Unknown profiles become error results with a hint
+	result, err := WithErrorResults(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		_, err := prismClientFor(request)
+		return nil, err
+	})(context.Background(), request)
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	text := result.Content[0].(mcp.TextContent).Text
+	assert.Contains(t, text, `unknown profile "errors-missing"`)
+	assert.Contains(t, text, "MCP_PROFILES")
+
+	// Errors of the v3 client are classified by their message
+	result, err = WithErrorResults(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return nil, errors.New("failed to list vm: invalid Nutanix credentials")
+	})(context.Background(), request)
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, client.ErrAuthFailed.Error())
+
+	// Panics become error results
+	result, err = WithErrorResults(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		panic("boom")
+	})(context.Background(), request)
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "vm_list failed unexpectedly: boom")
+
+	// Other errors are returned unchanged
+	other := errors.New("namespace, version and path are required")
+	_, err = WithErrorResults(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return nil, other
+	})(context.Background(), request)
+	assert.Equal(t, other, err)
+}
@@ -0,0 +1,152 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// fiqlComparisons lists the comparison operators understood by Prism FIQL filters
+var fiqlComparisons = []string{"==", "!=", "=lt=", "=le=", "=gt=", "=ge=", "=in=", "=out="}
+
+// parseFilterArgument reads the optional filter argument from a tool request
+// and validates it as a Prism FIQL expression
+func parseFilterArgument(request mcp.CallToolRequest) (string, error) {
+	if request.Params.Arguments == nil {
+		return "", nil
+	}
+
+	raw, ok := request.Params.Arguments["filter"].(string)
+	if !ok {
+		return "", nil
+	}
+
+	filter := strings.TrimSpace(raw)
+	if filter == "" {
+		return "", nil
+	}
+
+	if err := validateFIQL(filter); err != nil {
+		return "", fmt.Errorf("invalid filter %q: %w", filter, err)
+	}
+
+	return filter, nil
+}
+
+// validateFIQL checks that a filter follows the FIQL grammar used by Prism:
+//
+//	expression = and-expression *( "," and-expression )
+//	and-expression = constraint *( ";" constraint )
+//	constraint = "(" expression ")" / selector comparison argument
+func validateFIQL(filter string) error {
+	p := &fiqlParser{input: filter}
+	if err := p.parseExpression(); err != nil {
+		return err
+	}
+	if p.pos != len(p.input) {
+		return fmt.Errorf("unexpected %q at position %d", p.input[p.pos], p.pos)
+	}
+
+	return nil
+}
+
+type fiqlParser struct {
+	input string
+	pos   int
+}
+
+func (p *fiqlParser) parseExpression() error {
+	for {
+		if err := p.parseAndExpression(); err != nil {
+			return err
+		}
+		if !p.consume(',') {
+			return nil
+		}
+	}
+}
+
+func (p *fiqlParser) parseAndExpression() error {
+	for {
+		if err := p.parseConstraint(); err != nil {
+			return err
+		}
+		if !p.consume(';') {
+			return nil
+		}
+	}
+}
+
+func (p *fiqlParser) parseConstraint() error {
+	if p.consume('(') {
+		if err := p.parseExpression(); err != nil {
+			return err
+		}
+		if !p.consume(')') {
+			return fmt.Errorf("missing closing parenthesis at position %d", p.pos)
+		}
+		return nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.input) && isFIQLSelectorChar(p.input[p.pos]) {
+		p.pos++
+	}
+	if p.pos == start {
+		return fmt.Errorf("expected attribute name at position %d", p.pos)
+	}
+	selector := p.input[start:p.pos]
+
+	comparison := ""
+	for _, op := range fiqlComparisons {
+		if strings.HasPrefix(p.input[p.pos:], op) {
+			comparison = op
+			break
+		}
+	}
+	if comparison == "" {
+		return fmt.Errorf("expected comparison operator (%s) after %q", strings.Join(fiqlComparisons, ", "), selector)
+	}
+	p.pos += len(comparison)
+
+	start = p.pos
+	depth := 0
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		// Separators inside parentheses belong to the argument, e.g. =in=(a,b) lists or regex groups
+		if (c == ';' || c == ',') && depth == 0 {
+			break
+		}
+		if c == '(' {
+			depth++
+		} else if c == ')' {
+			if depth == 0 {
+				break
+			}
+			depth--
+		}
+		p.pos++
+	}
+	if p.pos == start {
+		return fmt.Errorf("missing value for %q%s", selector, comparison)
+	}
+	if depth > 0 {
+		return fmt.Errorf("missing closing parenthesis in value of %q%s", selector, comparison)
+	}
+
+	return nil
+}
+
+func (p *fiqlParser) consume(c byte) bool {
+	if p.pos < len(p.input) && p.input[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func isFIQLSelectorChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') ||
+		c == '_' || c == '.' || c == '-'
+}
@@ -0,0 +1,38 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFIQL(t *testing.T) {
+	valid := []string{
+		"vm_name==web.*",
+		"vm_name==web.*;power_state==on",
+		"power_state==on,power_state==off",
+		"(vm_name==a,vm_name==b);num_vcpus_per_socket=gt=2",
+		"vm_name==web-(01|02)",
+		"vm_name=in=(a,b)",
+		"power_state=out=(on;off)",
+		"vm_name=in=(a,b);power_state=out=(on;off),vm_name==c",
+	}
+	for _, filter := range valid {
+		assert.NoError(t, validateFIQL(filter), filter)
+	}
+
+	invalid := []string{
+		"vm_name",
+		"vm_name==",
+		"==web",
+		"vm_name==web;",
+		"(vm_name==web",
+		"vm_name=~web",
+		"vm_name==web)",
+		"vm_name=in=(a,b",
+		"vm_name=in=(a,b);",
+	}
+	for _, filter := range invalid {
+		assert.Error(t, validateFIQL(filter), filter)
+	}
+}
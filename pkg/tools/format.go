@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/json"
+	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// withFormatArgument adds the output format argument to a list tool
+func withFormatArgument() mcp.ToolOption {
+	names := make([]string, len(json.Formats))
+	for i, format := range json.Formats {
+		names[i] = string(format)
+	}
+
+	return mcp.WithString("format",
+		mcp.Description("Optional output format: json (default), pretty, yaml, or a csv or markdown table with one row per entity and the requested fields as columns"),
+		mcp.Enum(names...),
+	)
+}
+
+// parseFormatArgument reads the optional format argument of a list tool
+func parseFormatArgument(request mcp.CallToolRequest) (json.Format, error) {
+	return json.ParseFormat(stringArgument(request, "format"))
+}
+
+// newFormattedResult renders JSON output in the requested format and truncates it to the response budget.
+// Tables use the given columns, or every scalar entity field when there are none.
+// The links of the entities a response returns follow the extra contents.
+func newFormattedResult(request mcp.CallToolRequest, format json.Format, data []byte, columns []string, links *entityLinks, extra ...mcp.Content) (*mcp.CallToolResult, error) {
+	if format == json.FormatJSON {
+		return newJSONResult(request, data, links, extra...)
+	}
+
+	if format.IsTable() {
+		header, rows, err := json.RenderTable(data, format, columns)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render %s output: %w", format, err)
+		}
+		return newRowsResult(request, header, rows, links, extra...)
+	}
+
+	text, err := json.Render(data, format, columns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s output: %w", format, err)
+	}
+
+	return newTextResult(request, text, links, extra...)
+}
+
+// withDetailArgument adds the strip profile argument to a list tool
+func withDetailArgument() mcp.ToolOption {
+	return mcp.WithString("detail",
+		mcp.Description("Optional strip profile: minimal, default or full, or a profile configured with MCP_STRIP_PROFILES"),
+	)
+}
+
+// parseDetailArgument returns the paths to strip for the detail argument of a list tool
+func parseDetailArgument(resourceType resources.ResourceType, request mcp.CallToolRequest) ([]string, error) {
+	return json.StripPathsFor(string(resourceType), stringArgument(request, "detail"))
+}
+
+// withViewArgument adds the view argument to the list tool of a resource type supporting the summary view
+func withViewArgument() mcp.ToolOption {
+	return mcp.WithString("view",
+		mcp.Description("Optional view: full (default) returns the raw entities, summary a flattened record per entity with the most used fields"),
+		mcp.Enum(string(resources.ViewFull), string(resources.ViewSummary)),
+	)
+}
+
+// parseViewArgument reads the optional view argument of a list tool
+func parseViewArgument(resourceType resources.ResourceType, request mcp.CallToolRequest) (resources.View, error) {
+	return resources.ParseView(resourceType, stringArgument(request, "view"))
+}
+
+// withJQArgument adds the jq expression argument to a list tool
+func withJQArgument() mcp.ToolOption {
+	return mcp.WithString("jq",
+		mcp.Description("Optional jq expression run over the page before it is returned, e.g. .entities[] | select(.status.resources.num_sockets > 4) | .spec.name. "+
+			"The output is always a JSON array holding every value the expression produces, even when there is only one"),
+	)
+}
+
+// parseJQArgument compiles the optional jq expression of a list tool
+func parseJQArgument(request mcp.CallToolRequest) (*json.Query, error) {
+	expression := stringArgument(request, "jq")
+	if expression == "" {
+		return nil, nil
+	}
+
+	return json.CompileQuery(expression)
+}
+
+// runJQQuery runs a compiled jq expression over the JSON output of a list tool, if any
+func runJQQuery(ctx context.Context, query *json.Query, data []byte) ([]byte, error) {
+	if query == nil {
+		return data, nil
+	}
+
+	return query.Run(ctx, data, json.DefaultQueryLimits)
+}
@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFormattedResultRepeatsTableHeader(t *testing.T) {
+	data := []byte(`{"entities":[{"name":"vm-01"},{"name":"vm-02"},{"name":"vm-03"}]}`)
+
+	result, err := newFormattedResult(maxBytesRequest(float64(40)), json.FormatMarkdown, data, []string{"name"}, nil, mcp.NewTextContent("page"))
+	require.NoError(t, err)
+	texts := resultTexts(t, result)
+	require.Len(t, texts, 3)
+	assert.Equal(t, "| name |\n| --- |\n| vm-01 |\n| vm-02 |\n", texts[0])
+	assert.Equal(t, "page", texts[1])
+
+	texts = continueResponse(t, truncationInfo(t, texts[2]).Continuation, float64(0))
+	assert.Equal(t, []string{"| name |\n| --- |\n| vm-03 |\n"}, texts)
+}
+
+func TestParseFormatArgument(t *testing.T) {
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]interface{}{"format": "yaml"}
+	format, err := parseFormatArgument(request)
+	require.NoError(t, err)
+	assert.Equal(t, json.FormatYAML, format)
+
+	request.Params.Arguments["format"] = "toml"
+	_, err = parseFormatArgument(request)
+	assert.Error(t, err)
+}
+
+func TestNewFormattedResultKeepsCSVRecordsWhole(t *testing.T) {
+	data := []byte(`{"entities":[{"name":"vm-01","description":"first\nline"},{"name":"vm-02","description":"second\nline"}]}`)
+
+	result, err := newFormattedResult(maxBytesRequest(float64(40)), json.FormatCSV, data, []string{"name", "description"}, nil)
+	require.NoError(t, err)
+	texts := resultTexts(t, result)
+	require.Len(t, texts, 2)
+	assert.Equal(t, "name,description\nvm-01,\"first\nline\"\n", texts[0])
+
+	info := truncationInfo(t, texts[1])
+	assert.Equal(t, "rows", info.Unit)
+	assert.Equal(t, 1, info.Remaining)
+
+	texts = continueResponse(t, info.Continuation, float64(0))
+	assert.Equal(t, []string{"name,description\nvm-02,\"second\nline\"\n"}, texts)
+}
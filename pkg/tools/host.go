@@ -0,0 +1,93 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// HostList defines the Host list tool
+func HostList() mcp.Tool {
+	opts := []mcp.ToolOption{
+		mcp.WithDescription("List host resources one page at a time"),
+		mcp.WithString("filter",
+			mcp.Description("Optional Prism FIQL filter, e.g. name==prod.* (';' is AND, ',' is OR)"),
+		),
+		mcp.WithString("fields",
+			mcp.Description("Optional comma-separated entity field paths to return, e.g. spec.name,metadata.uuid"),
+		),
+		withDetailArgument(),
+		withJQArgument(),
+		withFormatArgument(),
+		withMaxBytesArgument(),
+		withProfileArgument(),
+	}
+
+	return mcp.NewTool("host_list", append(opts, withPagingArguments()...)...)
+}
+
+// HostListHandler implements the handler for the Host list tool
+func HostListHandler() server.ToolHandlerFunc {
+	return CreateListToolHandler(
+		resources.ResourceTypeHost,
+		// Define the ListResourceFunc implementation
+		func(ctx context.Context, client *client.NutanixClient, opts ListOptions) (interface{}, error) {
+			v3Client, err := client.V3()
+			if err != nil {
+				return nil, err
+			}
+
+			// Fetch a single page using the paging and sorting options
+			return v3Client.ListHost(ctx, opts.DSMetadata("host"))
+		},
+	)
+}
+
+// HostCount defines the Host count tool
+func HostCount() mcp.Tool {
+	return mcp.NewTool("host_count",
+		mcp.WithDescription("Count host resources"),
+		mcp.WithString("filter",
+			mcp.Description("Optional Prism FIQL filter, e.g. name==prod.* (';' is AND, ',' is OR)"),
+		),
+		withProfileArgument(),
+	)
+}
+
+// HostCountHandler implements the handler for the Host count tool
+func HostCountHandler() server.ToolHandlerFunc {
+	return CreateCountToolHandler(
+		resources.ResourceTypeHost,
+		// Define the ListResourceFunc implementation
+		func(ctx context.Context, client *client.NutanixClient, opts ListOptions) (interface{}, error) {
+			v3Client, err := client.V3()
+			if err != nil {
+				return nil, err
+			}
+
+			// Fetch a single entity; the server-side total covers the rest
+			opts.Length = 1
+			resp, err := v3Client.ListHost(ctx, opts.DSMetadata("host"))
+			if err != nil {
+				return nil, err
+			}
+			count, ok := totalMatches(resp)
+			if !ok {
+				return nil, fmt.Errorf("response has no total_matches")
+			}
+
+			res := map[string]interface{}{
+				"resource_type": "Host",
+				"count":         count,
+				"metadata":      resp.Metadata,
+			}
+
+			return res, nil
+		},
+	)
+}
@@ -0,0 +1,93 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ImageList defines the Image list tool
+func ImageList() mcp.Tool {
+	opts := []mcp.ToolOption{
+		mcp.WithDescription("List image resources one page at a time"),
+		mcp.WithString("filter",
+			mcp.Description("Optional Prism FIQL filter, e.g. name==prod.* (';' is AND, ',' is OR)"),
+		),
+		mcp.WithString("fields",
+			mcp.Description("Optional comma-separated entity field paths to return, e.g. spec.name,metadata.uuid"),
+		),
+		withDetailArgument(),
+		withJQArgument(),
+		withFormatArgument(),
+		withMaxBytesArgument(),
+		withProfileArgument(),
+	}
+
+	return mcp.NewTool("image_list", append(opts, withPagingArguments()...)...)
+}
+
+// ImageListHandler implements the handler for the Image list tool
+func ImageListHandler() server.ToolHandlerFunc {
+	return CreateListToolHandler(
+		resources.ResourceTypeImage,
+		// Define the ListResourceFunc implementation
+		func(ctx context.Context, client *client.NutanixClient, opts ListOptions) (interface{}, error) {
+			v3Client, err := client.V3()
+			if err != nil {
+				return nil, err
+			}
+
+			// Fetch a single page using the paging and sorting options
+			return v3Client.ListImage(ctx, opts.DSMetadata("image"))
+		},
+	)
+}
+
+// ImageCount defines the Image count tool
+func ImageCount() mcp.Tool {
+	return mcp.NewTool("image_count",
+		mcp.WithDescription("Count image resources"),
+		mcp.WithString("filter",
+			mcp.Description("Optional Prism FIQL filter, e.g. name==prod.* (';' is AND, ',' is OR)"),
+		),
+		withProfileArgument(),
+	)
+}
+
+// ImageCountHandler implements the handler for the Image count tool
+func ImageCountHandler() server.ToolHandlerFunc {
+	return CreateCountToolHandler(
+		resources.ResourceTypeImage,
+		// Define the ListResourceFunc implementation
+		func(ctx context.Context, client *client.NutanixClient, opts ListOptions) (interface{}, error) {
+			v3Client, err := client.V3()
+			if err != nil {
+				return nil, err
+			}
+
+			// Fetch a single entity; the server-side total covers the rest
+			opts.Length = 1
+			resp, err := v3Client.ListImage(ctx, opts.DSMetadata("image"))
+			if err != nil {
+				return nil, err
+			}
+			count, ok := totalMatches(resp)
+			if !ok {
+				return nil, fmt.Errorf("response has no total_matches")
+			}
+
+			res := map[string]interface{}{
+				"resource_type": "Image",
+				"count":         count,
+				"metadata":      resp.Metadata,
+			}
+
+			return res, nil
+		},
+	)
+}
@@ -23,20 +23,19 @@ func KernelLogsCritical() mcp.Tool {
 		mcp.WithString("lines",
 			mcp.Description("Optional number of lines to return (default 50, max 500)"),
 		),
+		withMaxBytesArgument(),
 	)
 }
 
 // KernelLogsCriticalHandler implements the handler for the kernel_logs_critical tool.
 func KernelLogsCriticalHandler() server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		_ = ctx
-
 		lines, err := parseKernelLogLines(request)
 		if err != nil {
 			return nil, err
 		}
 
-		cfg, err := getSSHConfig()
+		cfg, err := getSSHConfig(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -55,7 +54,7 @@ func KernelLogsCriticalHandler() server.ToolHandlerFunc {
 			return nil, err
 		}
 
-		return mcp.NewToolResultText(string(output)), nil
+		return newTextResult(request, string(output), nil)
 	}
 }
 
@@ -0,0 +1,136 @@
+package tools
+
+import (
+	"strings"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/json"
+	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// EntityLink is the text of the embedded resource returned for each entity of a list
+type EntityLink struct {
+	Name string `json:"name,omitempty"`
+	UUID string `json:"uuid"`
+}
+
+// entityLinks collects an embedded resource for every entity of a list response, pointing at its resource URI.
+// Only the links of the entities a response returns are added to it, see in.
+type entityLinks struct {
+	resourceType resources.ResourceType
+	profile      string
+	links        []collectedLink
+}
+
+// collectedLink is the embedded resource of an entity with its URI and size in bytes
+type collectedLink struct {
+	uri     string
+	content mcp.Content
+	size    int64
+}
+
+// newEntityLinks returns the collector of the entity links of a resource type.
+// The URIs select the connection profile the entities were listed from.
+func newEntityLinks(resourceType resources.ResourceType, profile string) *entityLinks {
+	return &entityLinks{resourceType: resourceType, profile: profile}
+}
+
+// EntityURI returns the resource URI of a decoded list entity, e.g. vm://{uuid}, and collects its link.
+// It is set as the EntityURI of the JSON encoder, which calls it once per entity before shaping.
+func (l *entityLinks) EntityURI(entity map[string]interface{}) string {
+	id := entityID(entity)
+
+	// Entities without an id, such as category keys, have no resource URI
+	if id == "" {
+		return ""
+	}
+	uri := resources.ProfileURI(resources.NutanixURI(l.resourceType, id), l.profile)
+
+	linkBytes, err := json.RegularJSONEncoder(EntityLink{Name: entityName(entity), UUID: id}).MarshalJSON()
+	if err == nil {
+		l.links = append(l.links, collectedLink{
+			uri: uri,
+			content: mcp.NewEmbeddedResource(mcp.TextResourceContents{
+				URI:      uri,
+				MIMEType: "application/json",
+				Text:     string(linkBytes),
+			}),
+			size: int64(len(uri) + len(linkBytes)),
+		})
+	}
+
+	return uri
+}
+
+// in returns the embedded resources of the entities whose URI occurs in a rendered response, with their size in bytes.
+// Entities cut by truncation or filtered out by jq are not linked. A nil collector has no links.
+func (l *entityLinks) in(text string) ([]mcp.Content, int64) {
+	if l == nil {
+		return nil, 0
+	}
+
+	var (
+		contents []mcp.Content
+		size     int64
+	)
+	for _, link := range l.links {
+		if containsURI(text, link.uri) {
+			contents = append(contents, link.content)
+			size += link.size
+		}
+	}
+
+	return contents, size
+}
+
+// containsURI reports whether a URI occurs in a text as a whole, e.g. vm://{uuid} is not found in vm://{uuid}?profile=dr
+func containsURI(text, uri string) bool {
+	for start := 0; ; {
+		i := strings.Index(text[start:], uri)
+		if i < 0 {
+			return false
+		}
+		i += start
+		end := i + len(uri)
+		if (i == 0 || !isURIByte(text[i-1])) && (end == len(text) || !isURIByte(text[end])) {
+			return true
+		}
+		start = i + 1
+	}
+}
+
+// isURIByte reports whether a byte can be part of an entity URI
+func isURIByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || strings.IndexByte("-_.~:/?=&%", b) >= 0
+}
+
+// entityID returns the id of a decoded list entity, read from metadata.uuid of v3 entities,
+// extId of v4 entities or uuid of summaries
+func entityID(entity map[string]interface{}) string {
+	var id string
+	if metadata, ok := entity["metadata"].(map[string]interface{}); ok {
+		id, _ = metadata["uuid"].(string)
+	}
+	for _, key := range []string{"extId", "uuid"} {
+		if id == "" {
+			id, _ = entity[key].(string)
+		}
+	}
+
+	return id
+}
+
+// entityName returns the name of a decoded list entity, preferring the observed status.name of v3 entities
+func entityName(entity map[string]interface{}) string {
+	for _, key := range []string{"status", "spec"} {
+		if object, ok := entity[key].(map[string]interface{}); ok {
+			if name, _ := object["name"].(string); name != "" {
+				return name
+			}
+		}
+	}
+	name, _ := entity["name"].(string)
+
+	return name
+}
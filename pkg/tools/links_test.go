@@ -0,0 +1,134 @@
+package tools
+
+import (
+	"context"
+	stdjson "encoding/json"
+	"testing"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+	"github.com/thunderboltsid/mcp-nutanix/internal/json"
+	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntityLinks(t *testing.T) {
+	links := newEntityLinks(resources.ResourceTypeVM, client.DefaultProfile)
+	assert.Equal(t, "vm://uuid-1", links.EntityURI(map[string]interface{}{
+		"metadata": map[string]interface{}{"uuid": "uuid-1"},
+		"spec":     map[string]interface{}{"name": "web-01"},
+		"status":   map[string]interface{}{"name": "web-01-renamed"},
+	}))
+	assert.Equal(t, "vm://uuid-2", links.EntityURI(map[string]interface{}{"name": "web-02", "uuid": "uuid-2"}))
+	// Entities without an id have no URI and no link
+	assert.Empty(t, links.EntityURI(map[string]interface{}{"name": "orphan"}))
+
+	// Only the entities occurring in the response are linked
+	contents, size := links.in(`[{"uri":"vm://uuid-1"}]`)
+	require.Len(t, contents, 1)
+	text, ok := contents[0].(mcp.EmbeddedResource).Resource.(mcp.TextResourceContents)
+	require.True(t, ok)
+	assert.Equal(t, "vm://uuid-1", text.URI)
+	assert.Equal(t, "application/json", text.MIMEType)
+	assert.JSONEq(t, `{"name":"web-01-renamed","uuid":"uuid-1"}`, text.Text)
+	assert.Equal(t, int64(len(text.URI)+len(text.Text)), size)
+	contents, _ = links.in("vm://uuid-1 vm://uuid-2")
+	assert.Len(t, contents, 2)
+	contents, _ = links.in("vm://uuid-10 vm://uuid-2?profile=dr")
+	assert.Empty(t, contents)
+
+	// v4 entities are addressed by extId, and URIs of other profiles select the profile
+	links = newEntityLinks(resources.ResourceTypeVmmVM, "dr")
+	assert.Equal(t, "vmm_vm://ext-1?profile=dr", links.EntityURI(map[string]interface{}{"extId": "ext-1", "name": "web-01"}))
+	contents, _ = links.in(`{"uri":"vmm_vm://ext-1?profile=dr"}`)
+	require.Len(t, contents, 1)
+	assert.Equal(t, "vmm_vm://ext-1?profile=dr", contents[0].(mcp.EmbeddedResource).Resource.(mcp.TextResourceContents).URI)
+}
+
+func TestListEntitiesCarryURI(t *testing.T) {
+	str := func(s string) *string { return &s }
+	resp := &v3.VMListIntentResponse{
+		Entities: []*v3.VMIntentResource{
+			{Metadata: &v3.Metadata{UUID: str("uuid-1")}, Spec: &v3.VM{Name: str("web-01")}},
+			{Spec: &v3.VM{Name: str("orphan")}},
+		},
+	}
+
+	// The URI is kept when fields project the rest of the entity away
+	cjson := json.StrippedJSONEncoder(resp, json.DefaultStripPaths, []string{"entities[].spec.name"})
+	cjson.EntityURI = newEntityLinks(resources.ResourceTypeVM, client.DefaultProfile).EntityURI
+	data, err := cjson.MarshalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"entities":[{"spec":{"name":"web-01"},"uri":"vm://uuid-1"},{"spec":{"name":"orphan"}}]}`, string(data))
+}
+
+// embeddedURIs returns the URIs of the embedded resources of a tool result
+func embeddedURIs(result *mcp.CallToolResult) []string {
+	var uris []string
+	for _, content := range result.Content {
+		if embedded, ok := mcp.AsEmbeddedResource(content); ok {
+			uris = append(uris, embedded.Resource.(mcp.TextResourceContents).URI)
+		}
+	}
+	return uris
+}
+
+func TestListToolEmbedsEntityResources(t *testing.T) {
+	client.InitProfile("links", client.NewMCPModelContextClient(map[string]string{"endpoint": "10.0.0.1"}))
+
+	str := func(s string) *string { return &s }
+	listFunc := func(ctx context.Context, _ *client.NutanixClient, opts ListOptions) (interface{}, error) {
+		return &v3.VMListIntentResponse{
+			Entities: []*v3.VMIntentResource{
+				{Metadata: &v3.Metadata{UUID: str("uuid-1")}, Spec: &v3.VM{Name: str("web-01")}},
+				{Metadata: &v3.Metadata{UUID: str("uuid-2")}, Spec: &v3.VM{Name: str("db-01")}},
+			},
+			Metadata: &v3.ListMetadataOutput{TotalMatches: func(n int64) *int64 { return &n }(2)},
+		}, nil
+	}
+	list := func(arguments map[string]interface{}) *mcp.CallToolResult {
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = map[string]interface{}{"profile": "links"}
+		for key, value := range arguments {
+			request.Params.Arguments[key] = value
+		}
+		result, err := CreateListToolHandler(resources.ResourceTypeVM, listFunc)(context.Background(), request)
+		require.NoError(t, err)
+		return result
+	}
+
+	// The entities follow the JSON text as embedded resources
+	result := list(nil)
+	var links []mcp.TextResourceContents
+	for _, content := range result.Content {
+		if embedded, ok := mcp.AsEmbeddedResource(content); ok {
+			links = append(links, embedded.Resource.(mcp.TextResourceContents))
+		}
+	}
+	require.Len(t, links, 2)
+	assert.Equal(t, "vm://uuid-1?profile=links", links[0].URI)
+	assert.JSONEq(t, `{"name":"web-01","uuid":"uuid-1"}`, links[0].Text)
+	assert.Equal(t, "vm://uuid-2?profile=links", links[1].URI)
+	assert.JSONEq(t, `{"name":"db-01","uuid":"uuid-2"}`, links[1].Text)
+
+	// Entities filtered out by jq are not linked
+	result = list(map[string]interface{}{"jq": `.entities[] | select(.spec.name == "db-01")`})
+	assert.Equal(t, []string{"vm://uuid-2?profile=links"}, embeddedURIs(result))
+
+	// Links count against the response budget and are returned with their entities
+	result = list(map[string]interface{}{"max_bytes": float64(250)})
+	assert.Equal(t, []string{"vm://uuid-1?profile=links"}, embeddedURIs(result))
+	assert.LessOrEqual(t, len(result.Content[0].(mcp.TextContent).Text)+len(links[0].URI)+len(links[0].Text), 250)
+
+	var info TruncationInfo
+	require.NoError(t, stdjson.Unmarshal([]byte(result.Content[len(result.Content)-1].(mcp.TextContent).Text), &info))
+	require.True(t, info.Truncated)
+	request := maxBytesRequest(float64(250))
+	request.Params.Arguments["continuation"] = info.Continuation
+	result, err := ResponseContinueHandler()(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vm://uuid-2?profile=links"}, embeddedURIs(result))
+}
@@ -0,0 +1,93 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// NetworkSecurityRuleList defines the NetworkSecurityRule list tool
+func NetworkSecurityRuleList() mcp.Tool {
+	opts := []mcp.ToolOption{
+		mcp.WithDescription("List network_security_rule resources one page at a time"),
+		mcp.WithString("filter",
+			mcp.Description("Optional Prism FIQL filter, e.g. name==prod.* (';' is AND, ',' is OR)"),
+		),
+		mcp.WithString("fields",
+			mcp.Description("Optional comma-separated entity field paths to return, e.g. spec.name,metadata.uuid"),
+		),
+		withDetailArgument(),
+		withJQArgument(),
+		withFormatArgument(),
+		withMaxBytesArgument(),
+		withProfileArgument(),
+	}
+
+	return mcp.NewTool("network_security_rule_list", append(opts, withPagingArguments()...)...)
+}
+
+// NetworkSecurityRuleListHandler implements the handler for the NetworkSecurityRule list tool
+func NetworkSecurityRuleListHandler() server.ToolHandlerFunc {
+	return CreateListToolHandler(
+		resources.ResourceTypeNetworkSecurityRule,
+		// Define the ListResourceFunc implementation
+		func(ctx context.Context, client *client.NutanixClient, opts ListOptions) (interface{}, error) {
+			v3Client, err := client.V3()
+			if err != nil {
+				return nil, err
+			}
+
+			// Fetch a single page using the paging and sorting options
+			return v3Client.ListNetworkSecurityRule(ctx, opts.DSMetadata("network_security_rule"))
+		},
+	)
+}
+
+// NetworkSecurityRuleCount defines the NetworkSecurityRule count tool
+func NetworkSecurityRuleCount() mcp.Tool {
+	return mcp.NewTool("network_security_rule_count",
+		mcp.WithDescription("Count network_security_rule resources"),
+		mcp.WithString("filter",
+			mcp.Description("Optional Prism FIQL filter, e.g. name==prod.* (';' is AND, ',' is OR)"),
+		),
+		withProfileArgument(),
+	)
+}
+
+// NetworkSecurityRuleCountHandler implements the handler for the NetworkSecurityRule count tool
+func NetworkSecurityRuleCountHandler() server.ToolHandlerFunc {
+	return CreateCountToolHandler(
+		resources.ResourceTypeNetworkSecurityRule,
+		// Define the ListResourceFunc implementation
+		func(ctx context.Context, client *client.NutanixClient, opts ListOptions) (interface{}, error) {
+			v3Client, err := client.V3()
+			if err != nil {
+				return nil, err
+			}
+
+			// Fetch a single entity; the server-side total covers the rest
+			opts.Length = 1
+			resp, err := v3Client.ListNetworkSecurityRule(ctx, opts.DSMetadata("network_security_rule"))
+			if err != nil {
+				return nil, err
+			}
+			count, ok := totalMatches(resp)
+			if !ok {
+				return nil, fmt.Errorf("response has no total_matches")
+			}
+
+			res := map[string]interface{}{
+				"resource_type": "NetworkSecurityRule",
+				"count":         count,
+				"metadata":      resp.Metadata,
+			}
+
+			return res, nil
+		},
+	)
+}
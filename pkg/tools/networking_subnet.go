@@ -0,0 +1,59 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// listNetworkingSubnet fetches a single page of NetworkingSubnet resources from the v4 API
+func listNetworkingSubnet(ctx context.Context, client *client.NutanixClient, opts V4ListOptions) (interface{}, error) {
+	v4Client, err := client.V4()
+	if err != nil {
+		return nil, err
+	}
+
+	return v4Client.SubnetsApiInstance.ListSubnets(opts.Page, opts.Limit, opts.Filter, opts.OrderBy, nil, opts.Select)
+}
+
+// NetworkingSubnetList defines the NetworkingSubnet list tool
+func NetworkingSubnetList() mcp.Tool {
+	opts := []mcp.ToolOption{
+		mcp.WithDescription("List networking_subnet resources one page at a time using the v4 API"),
+		mcp.WithString("filter",
+			mcp.Description("Optional OData $filter expression, e.g. startswith(name, 'prod')"),
+		),
+		withDetailArgument(),
+		withJQArgument(),
+		withFormatArgument(),
+		withMaxBytesArgument(),
+		withProfileArgument(),
+	}
+
+	return mcp.NewTool("networking_subnet_list", append(opts, withODataArguments()...)...)
+}
+
+// NetworkingSubnetListHandler implements the handler for the NetworkingSubnet list tool
+func NetworkingSubnetListHandler() server.ToolHandlerFunc {
+	return CreateV4ListToolHandler(resources.ResourceTypeNetworkingSubnet, listNetworkingSubnet)
+}
+
+// NetworkingSubnetCount defines the NetworkingSubnet count tool
+func NetworkingSubnetCount() mcp.Tool {
+	return mcp.NewTool("networking_subnet_count",
+		mcp.WithDescription("Count networking_subnet resources using the v4 API"),
+		mcp.WithString("filter",
+			mcp.Description("Optional OData $filter expression, e.g. startswith(name, 'prod')"),
+		),
+		withProfileArgument(),
+	)
+}
+
+// NetworkingSubnetCountHandler implements the handler for the NetworkingSubnet count tool
+func NetworkingSubnetCountHandler() server.ToolHandlerFunc {
+	return CreateV4CountToolHandler(resources.ResourceTypeNetworkingSubnet, listNetworkingSubnet)
+}
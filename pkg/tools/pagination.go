@@ -0,0 +1,320 @@
+package tools
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+)
+
+const (
+	defaultListLength = 50
+	maxListLength     = 500
+)
+
+// ListOptions holds the filter, paging and sorting options of a list call
+type ListOptions struct {
+	Filter        string `json:"f,omitempty"`
+	Offset        int64  `json:"o,omitempty"`
+	Length        int64  `json:"l,omitempty"`
+	SortAttribute string `json:"s,omitempty"`
+	SortOrder     string `json:"d,omitempty"`
+}
+
+// DSMetadata converts the options into the v3 list request metadata
+func (o ListOptions) DSMetadata(kind string) *v3.DSMetadata {
+	metadata := &v3.DSMetadata{Kind: &kind}
+	if o.Length > 0 {
+		metadata.Offset = &o.Offset
+		metadata.Length = &o.Length
+	}
+	if o.Filter != "" {
+		metadata.Filter = &o.Filter
+	}
+	if o.SortAttribute != "" {
+		metadata.SortAttribute = &o.SortAttribute
+		metadata.SortOrder = &o.SortOrder
+	}
+
+	return metadata
+}
+
+// CategoryListMetadata converts the options into the v3 category list request metadata
+func (o ListOptions) CategoryListMetadata(kind string) *v3.CategoryListMetadata {
+	metadata := &v3.CategoryListMetadata{Kind: &kind}
+	if o.Length > 0 {
+		metadata.Offset = &o.Offset
+		metadata.Length = &o.Length
+	}
+	if o.Filter != "" {
+		metadata.Filter = &o.Filter
+	}
+	if o.SortAttribute != "" {
+		metadata.SortAttribute = &o.SortAttribute
+		metadata.SortOrder = &o.SortOrder
+	}
+
+	return metadata
+}
+
+// listCursor is the decoded form of the opaque next_cursor value.
+// It is bound to the resource type and connection profile it was issued for.
+type listCursor struct {
+	ResourceType resources.ResourceType `json:"t"`
+	Profile      string                 `json:"p"`
+	ListOptions
+}
+
+// PageInfo describes the page returned by a list tool
+type PageInfo struct {
+	TotalMatches int64  `json:"total_matches"`
+	Offset       int64  `json:"offset"`
+	Length       int64  `json:"length"`
+	Returned     int    `json:"returned"`
+	NextCursor   string `json:"next_cursor,omitempty"`
+}
+
+// withPagingArguments adds the paging and sorting arguments to a list tool
+func withPagingArguments() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithNumber("offset",
+			mcp.Description("Optional offset from the start of the result set (default 0)"),
+		),
+		mcp.WithNumber("length",
+			mcp.Description(fmt.Sprintf("Optional number of entities to return (default %d, max %d)", defaultListLength, maxListLength)),
+		),
+		mcp.WithString("sort_attribute",
+			mcp.Description("Optional attribute to sort on"),
+		),
+		mcp.WithString("sort_order",
+			mcp.Description("Optional sort order: ASCENDING or DESCENDING (default ASCENDING)"),
+		),
+		mcp.WithString("cursor",
+			mcp.Description("Optional next_cursor returned by a previous call; overrides the filter, paging and sorting arguments"),
+		),
+	}
+}
+
+// parseListOptions reads the filter, paging and sorting arguments from a list tool request of a connection profile
+func parseListOptions(resourceType resources.ResourceType, profile string, request mcp.CallToolRequest) (ListOptions, error) {
+	if cursor := stringArgument(request, "cursor"); cursor != "" {
+		return decodeListCursor(resourceType, profile, cursor)
+	}
+
+	filter, err := parseFilterArgument(request)
+	if err != nil {
+		return ListOptions{}, err
+	}
+
+	opts := ListOptions{
+		Filter: filter,
+		Length: defaultListLength,
+	}
+
+	if opts.Offset, err = int64Argument(request, "offset", 0); err != nil {
+		return ListOptions{}, err
+	}
+	if opts.Length, err = int64Argument(request, "length", defaultListLength); err != nil {
+		return ListOptions{}, err
+	}
+
+	opts.SortAttribute = stringArgument(request, "sort_attribute")
+	switch strings.ToUpper(stringArgument(request, "sort_order")) {
+	case "", "ASC", "ASCENDING":
+		opts.SortOrder = "ASCENDING"
+	case "DESC", "DESCENDING":
+		opts.SortOrder = "DESCENDING"
+	default:
+		return ListOptions{}, fmt.Errorf("sort_order must be ASCENDING or DESCENDING")
+	}
+
+	if err := validateListOptions(opts); err != nil {
+		return ListOptions{}, err
+	}
+
+	return opts, nil
+}
+
+// validateListOptions checks the filter, paging and sorting options of a list call,
+// whether they were read from the arguments or from a cursor
+func validateListOptions(opts ListOptions) error {
+	if opts.Filter != "" {
+		if err := validateFIQL(opts.Filter); err != nil {
+			return fmt.Errorf("invalid filter %q: %w", opts.Filter, err)
+		}
+	}
+	if opts.Offset < 0 {
+		return fmt.Errorf("offset must not be negative")
+	}
+	if opts.Length < 1 || opts.Length > maxListLength {
+		return fmt.Errorf("length must be between 1 and %d", maxListLength)
+	}
+	if opts.SortOrder != "ASCENDING" && opts.SortOrder != "DESCENDING" {
+		return fmt.Errorf("sort_order must be ASCENDING or DESCENDING")
+	}
+
+	return nil
+}
+
+// newPageInfo builds the paging summary for a list response of a connection profile
+func newPageInfo(resourceType resources.ResourceType, profile string, opts ListOptions, resp interface{}) (*PageInfo, error) {
+	page := &PageInfo{
+		Offset:   opts.Offset,
+		Length:   opts.Length,
+		Returned: entityCount(resp),
+	}
+
+	total, ok := totalMatches(resp)
+	if !ok {
+		total = opts.Offset + int64(page.Returned)
+	}
+	page.TotalMatches = total
+
+	next := opts.Offset + int64(page.Returned)
+	if page.Returned > 0 && next < total {
+		nextOpts := opts
+		nextOpts.Offset = next
+
+		cursor, err := encodeListCursor(resourceType, profile, nextOpts)
+		if err != nil {
+			return nil, err
+		}
+		page.NextCursor = cursor
+	}
+
+	return page, nil
+}
+
+func encodeListCursor(resourceType resources.ResourceType, profile string, opts ListOptions) (string, error) {
+	data, err := json.Marshal(listCursor{ResourceType: resourceType, Profile: profile, ListOptions: opts})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeListCursor(resourceType resources.ResourceType, profile string, cursor string) (ListOptions, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ListOptions{}, fmt.Errorf("invalid cursor: not produced by this server")
+	}
+
+	var decoded listCursor
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return ListOptions{}, fmt.Errorf("invalid cursor: not produced by this server")
+	}
+	if decoded.ResourceType != resourceType {
+		return ListOptions{}, fmt.Errorf("invalid cursor: issued for %s, not %s", decoded.ResourceType, resourceType)
+	}
+	if decoded.Profile != profile {
+		return ListOptions{}, fmt.Errorf("invalid cursor: issued for profile %q, not %q", decoded.Profile, profile)
+	}
+	if err := validateListOptions(decoded.ListOptions); err != nil {
+		return ListOptions{}, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	return decoded.ListOptions, nil
+}
+
+// entityCount returns the length of the Entities field of a v3 list response
+func entityCount(resp interface{}) int {
+	v := reflect.Indirect(reflect.ValueOf(resp))
+	if v.Kind() != reflect.Struct {
+		return 0
+	}
+
+	entities := v.FieldByName("Entities")
+	if !entities.IsValid() || entities.Kind() != reflect.Slice {
+		return 0
+	}
+
+	return entities.Len()
+}
+
+// totalMatches returns Metadata.TotalMatches of a v3 list response.
+// The v3 models disagree on whether it is a pointer, so both forms are handled.
+func totalMatches(resp interface{}) (int64, bool) {
+	v := reflect.Indirect(reflect.ValueOf(resp))
+	if v.Kind() != reflect.Struct {
+		return 0, false
+	}
+
+	metadata := v.FieldByName("Metadata")
+	if !metadata.IsValid() || (metadata.Kind() == reflect.Ptr && metadata.IsNil()) {
+		return 0, false
+	}
+	metadata = reflect.Indirect(metadata)
+	if metadata.Kind() != reflect.Struct {
+		return 0, false
+	}
+
+	total := metadata.FieldByName("TotalMatches")
+	if !total.IsValid() || (total.Kind() == reflect.Ptr && total.IsNil()) {
+		return 0, false
+	}
+	total = reflect.Indirect(total)
+	if total.Kind() != reflect.Int64 {
+		return 0, false
+	}
+
+	return total.Int(), true
+}
+
+// splitList splits a comma-separated argument into its trimmed, non-empty items
+func splitList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func stringArgument(request mcp.CallToolRequest, name string) string {
+	if request.Params.Arguments == nil {
+		return ""
+	}
+
+	raw, ok := request.Params.Arguments[name].(string)
+	if !ok {
+		return ""
+	}
+
+	return strings.TrimSpace(raw)
+}
+
+// int64Argument reads an integer argument sent either as a string or a JSON number
+func int64Argument(request mcp.CallToolRequest, name string, defaultValue int64) (int64, error) {
+	if request.Params.Arguments == nil {
+		return defaultValue, nil
+	}
+
+	switch raw := request.Params.Arguments[name].(type) {
+	case float64:
+		if raw != float64(int64(raw)) {
+			return 0, fmt.Errorf("%s must be an integer", name)
+		}
+		return int64(raw), nil
+	case string:
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			return defaultValue, nil
+		}
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%s must be an integer", name)
+		}
+		return parsed, nil
+	default:
+		return defaultValue, nil
+	}
+}
@@ -0,0 +1,112 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+	"github.com/nutanix-cloud-native/prism-go-client/v3/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseListOptions(t *testing.T) {
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]interface{}{
+		"filter":         "vm_name==web.*",
+		"offset":         "20",
+		"length":         float64(10),
+		"sort_attribute": "vm_name",
+		"sort_order":     "desc",
+	}
+
+	opts, err := parseListOptions(resources.ResourceTypeVM, client.DefaultProfile, request)
+	assert.NoError(t, err)
+	assert.Equal(t, ListOptions{
+		Filter:        "vm_name==web.*",
+		Offset:        20,
+		Length:        10,
+		SortAttribute: "vm_name",
+		SortOrder:     "DESCENDING",
+	}, opts)
+
+	request.Params.Arguments["length"] = "1000"
+	_, err = parseListOptions(resources.ResourceTypeVM, client.DefaultProfile, request)
+	assert.Error(t, err)
+}
+
+func TestPagingArgumentsAreNumbers(t *testing.T) {
+	tool := mcp.NewTool("paging_test", withPagingArguments()...)
+	for _, name := range []string{"offset", "length"} {
+		assert.Equal(t, "number", tool.InputSchema.Properties[name].(map[string]interface{})["type"], name)
+	}
+}
+
+func TestListCursorRoundTrip(t *testing.T) {
+	total := int64(25)
+	resp := &v3.VMListIntentResponse{
+		Entities: make([]*v3.VMIntentResource, 10),
+		Metadata: &v3.ListMetadataOutput{TotalMatches: &total},
+	}
+	opts := ListOptions{Filter: "power_state==on", Length: 10, SortOrder: "ASCENDING"}
+
+	page, err := newPageInfo(resources.ResourceTypeVM, client.DefaultProfile, opts, resp)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(25), page.TotalMatches)
+	assert.Equal(t, 10, page.Returned)
+	assert.NotEmpty(t, page.NextCursor)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]interface{}{"cursor": page.NextCursor}
+
+	next, err := parseListOptions(resources.ResourceTypeVM, client.DefaultProfile, request)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), next.Offset)
+	assert.Equal(t, "power_state==on", next.Filter)
+
+	// Cursors are bound to the resource type and connection profile they were issued for
+	_, err = parseListOptions(resources.ResourceTypeHost, client.DefaultProfile, request)
+	assert.Error(t, err)
+	_, err = parseListOptions(resources.ResourceTypeVM, "dr", request)
+	assert.ErrorContains(t, err, `issued for profile "default", not "dr"`)
+
+	// Cursors are validated like arguments
+	for _, invalid := range []ListOptions{
+		{Length: maxListLength + 1, SortOrder: "ASCENDING"},
+		{Length: 0, SortOrder: "ASCENDING"},
+		{Filter: "power_state", Length: 10, SortOrder: "ASCENDING"},
+		{Offset: -1, Length: 10, SortOrder: "ASCENDING"},
+	} {
+		cursor, err := encodeListCursor(resources.ResourceTypeVM, client.DefaultProfile, invalid)
+		assert.NoError(t, err)
+		request.Params.Arguments["cursor"] = cursor
+		_, err = parseListOptions(resources.ResourceTypeVM, client.DefaultProfile, request)
+		assert.ErrorContains(t, err, "invalid cursor", "%+v", invalid)
+	}
+
+	// The last page has no cursor
+	page, err = newPageInfo(resources.ResourceTypeVM, client.DefaultProfile, ListOptions{Offset: 20, Length: 10}, &v3.VMListIntentResponse{
+		Entities: make([]*v3.VMIntentResource, 5),
+		Metadata: &v3.ListMetadataOutput{TotalMatches: &total},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestTotalMatches(t *testing.T) {
+	total := int64(1200)
+
+	// Counts read the total of a single entity page, whether the total is a pointer or not
+	count, ok := totalMatches(&v3.VMListIntentResponse{Metadata: &v3.ListMetadataOutput{TotalMatches: &total}})
+	assert.True(t, ok)
+	assert.Equal(t, total, count)
+
+	count, ok = totalMatches(&models.RecoveryPlanListIntentResponse{Metadata: &models.RecoveryPlanListMetadataOutput{TotalMatches: total}})
+	assert.True(t, ok)
+	assert.Equal(t, total, count)
+
+	_, ok = totalMatches(&v3.VMListIntentResponse{})
+	assert.False(t, ok)
+}
@@ -0,0 +1,211 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	prismclientv4 "github.com/nutanix-cloud-native/prism-go-client/v4"
+)
+
+// routeParamPattern matches path placeholders such as {extId}
+var routeParamPattern = regexp.MustCompile(`\{([^{}/]+)\}`)
+
+// PrismAPIGet defines the prism_api_get tool
+func PrismAPIGet() mcp.Tool {
+	return mcp.NewTool("prism_api_get",
+		mcp.WithDescription("Call any read-only (GET) Prism Central v4 API route listed by api_namespaces_list"),
+		mcp.WithString("namespace",
+			mcp.Required(),
+			mcp.Description("API namespace, e.g. vmm"),
+		),
+		mcp.WithString("version",
+			mcp.Required(),
+			mcp.Description("API version of the namespace, e.g. v4.0"),
+		),
+		mcp.WithString("path",
+			mcp.Required(),
+			mcp.Description("Route path exactly as listed by api_namespaces_list, e.g. ahv/config/vms/{extId}"),
+		),
+		mcp.WithObject("path_params",
+			mcp.Description("Values for the route placeholders, e.g. {\"extId\": \"...\"}"),
+		),
+		mcp.WithObject("query_params",
+			mcp.Description("Optional query parameters, e.g. {\"$filter\": \"startswith(name, 'web')\", \"$limit\": 10}"),
+		),
+		withMaxBytesArgument(),
+		withProfileArgument(),
+	)
+}
+
+// PrismAPIGetHandler implements the handler for the prism_api_get tool
+func PrismAPIGetHandler() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get the Prism client of the selected profile
+		prismClient, err := prismClientFor(request)
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := stringArgument(request, "namespace")
+		version := stringArgument(request, "version")
+		routePath := stringArgument(request, "path")
+		if namespace == "" || version == "" || routePath == "" {
+			return nil, fmt.Errorf("namespace, version and path are required")
+		}
+
+		pathParams, err := objectArgument(request, "path_params")
+		if err != nil {
+			return nil, err
+		}
+		queryParams, err := objectArgument(request, "query_params")
+		if err != nil {
+			return nil, err
+		}
+
+		// Validate the route against the routes advertised by Prism Central
+		routes, err := prismClient.VersionRoutes(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get API routes: %w", err)
+		}
+		route, err := findRoute(routes, namespace, version, routePath)
+		if err != nil {
+			return nil, err
+		}
+
+		resolved, err := expandRoute(route, pathParams)
+		if err != nil {
+			return nil, err
+		}
+
+		query := url.Values{}
+		for key, value := range queryParams {
+			query.Set(key, value)
+		}
+
+		resp, err := prismClient.Get(ctx, fmt.Sprintf("/api/%s/%s/%s", namespace, version, resolved), query)
+		if err != nil {
+			return nil, err
+		}
+
+		// Convert to JSON
+		cjson := json.CustomJSONEncoder(resp)
+		jsonBytes, err := cjson.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal response: %w", err)
+		}
+
+		return newJSONResult(request, jsonBytes, nil, redactionContents(cjson.Redactions)...)
+	}
+}
+
+// findRoute returns the normalized route of a namespace and version matching the requested path
+func findRoute(routes []prismclientv4.ActuatorNamespaceVersionRoutes, namespace, version, routePath string) (string, error) {
+	var namespaces []string
+	for _, ns := range routes {
+		namespaces = append(namespaces, ns.Namespace)
+		if ns.Namespace != namespace {
+			continue
+		}
+
+		var versions []string
+		for _, vr := range ns.VersionRoutes {
+			versions = append(versions, vr.Version)
+			if vr.Version != version {
+				continue
+			}
+
+			wanted := normalizeRoute(namespace, version, routePath)
+			if strings.Contains(wanted, "$actions") {
+				return "", fmt.Errorf("route %s is an action and cannot be called with GET", routePath)
+			}
+			for _, route := range vr.Routes {
+				if normalizeRoute(namespace, version, route) == wanted {
+					return wanted, nil
+				}
+			}
+
+			return "", fmt.Errorf("route %s not found in %s %s, use api_namespaces_list to see the available routes", routePath, namespace, version)
+		}
+
+		return "", fmt.Errorf("version %s not found in namespace %s, available versions: %s", version, namespace, strings.Join(versions, ", "))
+	}
+
+	sort.Strings(namespaces)
+	return "", fmt.Errorf("namespace %s not found, available namespaces: %s", namespace, strings.Join(namespaces, ", "))
+}
+
+// normalizeRoute strips the /api/{namespace}/{version}/ prefix and surrounding slashes from a route
+func normalizeRoute(namespace, version, route string) string {
+	route = strings.Trim(strings.TrimSpace(route), "/")
+	route = strings.TrimPrefix(route, fmt.Sprintf("api/%s/%s", namespace, version))
+	return strings.Trim(route, "/")
+}
+
+// expandRoute substitutes the route placeholders with escaped path parameters
+func expandRoute(route string, params map[string]string) (string, error) {
+	used := make(map[string]struct{}, len(params))
+	var missing, invalid []string
+
+	expanded := routeParamPattern.ReplaceAllStringFunc(route, func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+		value, ok := params[name]
+		if !ok || value == "" {
+			missing = append(missing, name)
+			return placeholder
+		}
+		used[name] = struct{}{}
+		if value == "." || value == ".." || strings.Contains(value, "/") {
+			invalid = append(invalid, name)
+			return placeholder
+		}
+		return url.PathEscape(value)
+	})
+
+	if len(missing) > 0 {
+		return "", fmt.Errorf("missing path_params for route %s: %s", route, strings.Join(missing, ", "))
+	}
+	if len(invalid) > 0 {
+		return "", fmt.Errorf("path_params %s must be a single path segment", strings.Join(invalid, ", "))
+	}
+	for name := range params {
+		if _, ok := used[name]; !ok {
+			return "", fmt.Errorf("path_params %s is not a placeholder of route %s", name, route)
+		}
+	}
+
+	return expanded, nil
+}
+
+// objectArgument reads an object argument whose values are scalars and converts them to strings
+func objectArgument(request mcp.CallToolRequest, name string) (map[string]string, error) {
+	result := map[string]string{}
+	if request.Params.Arguments == nil || request.Params.Arguments[name] == nil {
+		return result, nil
+	}
+
+	raw, ok := request.Params.Arguments[name].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be an object", name)
+	}
+
+	for key, value := range raw {
+		switch v := value.(type) {
+		case string:
+			result[key] = v
+		case float64, bool:
+			result[key] = fmt.Sprint(v)
+		default:
+			return nil, fmt.Errorf("%s.%s must be a string, number or boolean", name, key)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"testing"
+
+	prismclientv4 "github.com/nutanix-cloud-native/prism-go-client/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindAndExpandRoute(t *testing.T) {
+	routes := []prismclientv4.ActuatorNamespaceVersionRoutes{
+		{
+			Namespace: "vmm",
+			VersionRoutes: []prismclientv4.ActuatorVersionRoute{
+				{
+					Version: "v4.0",
+					Routes: []string{
+						"/api/vmm/v4.0/ahv/config/vms",
+						"/api/vmm/v4.0/ahv/config/vms/{extId}",
+						"/api/vmm/v4.0/ahv/config/vms/{extId}/$actions/power-on",
+					},
+				},
+			},
+		},
+	}
+
+	route, err := findRoute(routes, "vmm", "v4.0", "ahv/config/vms/{extId}")
+	assert.NoError(t, err)
+	assert.Equal(t, "ahv/config/vms/{extId}", route)
+
+	expanded, err := expandRoute(route, map[string]string{"extId": "a b"})
+	assert.NoError(t, err)
+	assert.Equal(t, "ahv/config/vms/a%20b", expanded)
+
+	for _, value := range []string{"..", ".", "../..", "a/b"} {
+		_, err = expandRoute(route, map[string]string{"extId": value})
+		assert.Error(t, err, value)
+	}
+
+	_, err = expandRoute(route, map[string]string{})
+	assert.Error(t, err)
+	_, err = expandRoute(route, map[string]string{"extId": "x", "other": "y"})
+	assert.Error(t, err)
+
+	_, err = findRoute(routes, "vmm", "v4.0", "ahv/config/vms/{extId}/$actions/power-on")
+	assert.Error(t, err)
+	_, err = findRoute(routes, "vmm", "v4.0", "ahv/config/templates")
+	assert.Error(t, err)
+	_, err = findRoute(routes, "vmm", "v3.1", "ahv/config/vms")
+	assert.Error(t, err)
+	_, err = findRoute(routes, "clustermgmt", "v4.0", "config/clusters")
+	assert.Error(t, err)
+}
@@ -0,0 +1,43 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+	"github.com/thunderboltsid/mcp-nutanix/internal/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// withProfileArgument adds the connection profile argument to a Prism tool
+func withProfileArgument() mcp.ToolOption {
+	return mcp.WithString("profile",
+		mcp.Description("Optional Prism Central connection profile, see profiles_list (default: "+client.DefaultProfile+")"),
+	)
+}
+
+// prismClientFor returns the client of the connection profile selected by a tool request
+func prismClientFor(request mcp.CallToolRequest) (*client.NutanixClient, error) {
+	return client.LookupProfile(stringArgument(request, "profile"))
+}
+
+// ProfilesList defines the profiles_list tool
+func ProfilesList() mcp.Tool {
+	return mcp.NewTool("profiles_list",
+		mcp.WithDescription("List the Prism Central connection profiles and whether they are configured, which can be selected with the profile argument of Prism tools"),
+	)
+}
+
+// ProfilesListHandler implements the handler for the profiles_list tool
+func ProfilesListHandler() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		jsonBytes, err := json.RegularJSONEncoder(client.Profiles()).MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal profiles: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonBytes)), nil
+	}
+}
@@ -0,0 +1,29 @@
+package tools
+
+import (
+	"github.com/thunderboltsid/mcp-nutanix/internal/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// redactionContents returns the audit of the secrets masked in a response as an extra content,
+// or nothing if no secret was masked
+func redactionContents(counts json.RedactionCounts) []mcp.Content {
+	audit := json.NewRedactionAudit(counts)
+	if audit == nil {
+		return nil
+	}
+
+	auditBytes, err := json.RegularJSONEncoder(audit).MarshalJSON()
+	if err != nil {
+		return nil
+	}
+
+	return []mcp.Content{mcp.NewTextContent(string(auditBytes))}
+}
+
+// redactText masks secrets in text output and returns the audit of the masked secrets
+func redactText(text string) (string, []mcp.Content) {
+	text, counts := json.RedactText(text)
+	return text, redactionContents(counts)
+}
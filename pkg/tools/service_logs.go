@@ -18,7 +18,6 @@ import (
 const (
 	envServiceHost     = "SSH_HOST"
 	envServiceUsername = "SSH_USERNAME"
-	envServicePassword = "SSH_PASSWORD"
 	envServicePort     = "SSH_PORT"
 	envSSHLogRoot      = "SSH_LOG_ROOT"
 )
@@ -36,38 +35,23 @@ func FetchService() mcp.Tool {
 // FetchServiceHandler implements the handler for the fetch_service tool
 func FetchServiceHandler() server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		_ = ctx
-
-		host := getEnvTrimmedService(envServiceHost)
-		username := getEnvTrimmedService(envServiceUsername)
-		password := getEnvTrimmedService(envServicePassword)
-		root := getEnvTrimmedService(envSSHLogRoot)
-		if host == "" {
-			return nil, fmt.Errorf("%s is required", envServiceHost)
-		}
-		if username == "" {
-			return nil, fmt.Errorf("%s is required", envServiceUsername)
-		}
-		if password == "" {
-			return nil, fmt.Errorf("%s is required", envServicePassword)
+		sshCfg, err := getSSHConfig(ctx)
+		if err != nil {
+			return nil, err
 		}
+		root := getEnvTrimmedService(envSSHLogRoot)
 		if root == "" {
 			return nil, fmt.Errorf("%s is required", envSSHLogRoot)
 		}
 
-		port, err := getEnvPortService(envServicePort, 22)
-		if err != nil {
-			return nil, err
-		}
-
 		cfg := &ServiceConfig{
-			Host:     host,
-			Port:     port,
-			User:     username,
-			Password: password,
+			Host:     sshCfg.Host,
+			Port:     sshCfg.Port,
+			User:     sshCfg.User,
+			Password: sshCfg.Password,
 			Root:     root,
 			Insecure: true,
-			Timeout:  10 * time.Second,
+			Timeout:  sshCfg.Timeout,
 		}
 
 		requestedPath := "narsil.out"
@@ -79,14 +63,6 @@ func FetchServiceHandler() server.ToolHandlerFunc {
 
 		resolvedPath := path.Join(cfg.Root, requestedPath)
 
-		sshCfg := &SSHConfig{
-			Host:     cfg.Host,
-			Port:     cfg.Port,
-			User:     cfg.User,
-			Password: cfg.Password,
-			Timeout:  cfg.Timeout,
-		}
-
 		hosts, err := getSSHHosts(sshCfg)
 		if err != nil {
 			return nil, err
@@ -108,6 +84,7 @@ func FetchServiceHandler() server.ToolHandlerFunc {
 				continue
 			}
 
+			// The file is saved unchanged for support, only the result returned to the model is redacted
 			outputPath := fmt.Sprintf("%s_%s", sanitizeHostForFileName(host), baseName)
 			if err := os.WriteFile(outputPath, data, 0644); err != nil {
 				outputBuilder.WriteString(fmt.Sprintf("ssh_host=%s error writing file: %s", host, err.Error()))
@@ -122,7 +99,8 @@ func FetchServiceHandler() server.ToolHandlerFunc {
 			return nil, fmt.Errorf("failed to fetch %s from all SSH hosts", resolvedPath)
 		}
 
-		return mcp.NewToolResultText(outputBuilder.String()), nil
+		// Mask secrets in the command output of failed hosts
+		return newTextResult(request, outputBuilder.String(), nil)
 	}
 }
 
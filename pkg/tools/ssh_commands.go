@@ -8,6 +8,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/thunderboltsid/mcp-nutanix/internal/credentials"
+
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"golang.org/x/crypto/ssh"
@@ -28,14 +30,13 @@ func SSHExec() mcp.Tool {
 		mcp.WithString("command",
 			mcp.Description("Command to execute on each SSH host"),
 		),
+		withMaxBytesArgument(),
 	)
 }
 
 // SSHExecHandler implements the handler for the ssh_exec tool
 func SSHExecHandler() server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		_ = ctx
-
 		command := ""
 		if request.Params.Arguments != nil {
 			if arg, ok := request.Params.Arguments["command"].(string); ok {
@@ -46,7 +47,7 @@ func SSHExecHandler() server.ToolHandlerFunc {
 			return nil, fmt.Errorf("command is required")
 		}
 
-		cfg, err := getSSHConfig()
+		cfg, err := getSSHConfig(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -56,7 +57,7 @@ func SSHExecHandler() server.ToolHandlerFunc {
 			return nil, err
 		}
 
-		return mcp.NewToolResultText(string(output)), nil
+		return newTextResult(request, string(output), nil)
 	}
 }
 
@@ -67,14 +68,13 @@ func SSHExecBatch() mcp.Tool {
 		mcp.WithString("commands",
 			mcp.Description("Newline-separated commands to execute in order on each SSH host"),
 		),
+		withMaxBytesArgument(),
 	)
 }
 
 // SSHExecBatchHandler implements the handler for the ssh_exec_batch tool
 func SSHExecBatchHandler() server.ToolHandlerFunc {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		_ = ctx
-
 		commandsRaw := ""
 		if request.Params.Arguments != nil {
 			if arg, ok := request.Params.Arguments["commands"].(string); ok {
@@ -86,7 +86,7 @@ func SSHExecBatchHandler() server.ToolHandlerFunc {
 			return nil, fmt.Errorf("commands is required")
 		}
 
-		cfg, err := getSSHConfig()
+		cfg, err := getSSHConfig(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -96,22 +96,30 @@ func SSHExecBatchHandler() server.ToolHandlerFunc {
 			return nil, err
 		}
 
-		return mcp.NewToolResultText(output), nil
+		return newTextResult(request, output, nil)
 	}
 }
 
-func getSSHConfig() (*SSHConfig, error) {
+// getSSHConfig returns the SSH configuration of the SSH_* environment variables, looking up the username
+// and password through the credential chain, e.g. from SSH_PASSWORD_FILE, a netrc file or a credential helper
+func getSSHConfig(ctx context.Context) (*SSHConfig, error) {
 	host := getEnvTrimmedService(envServiceHost)
-	username := getEnvTrimmedService(envServiceUsername)
-	password := getEnvTrimmedService(envServicePassword)
-	if host == "" {
+
+	// All hosts share the credentials, netrc entries match the first one
+	hosts := parseSSHHosts(host)
+	if len(hosts) == 0 {
 		return nil, fmt.Errorf("%s is required", envServiceHost)
 	}
-	if username == "" {
-		return nil, fmt.Errorf("%s is required", envServiceUsername)
+	creds, err := credentials.DefaultChain().Lookup(ctx, credentials.Request{
+		Service: "ssh",
+		Prefix:  "SSH",
+		Host:    hosts[0],
+	})
+	if err != nil {
+		return nil, err
 	}
-	if password == "" {
-		return nil, fmt.Errorf("%s is required", envServicePassword)
+	if creds.Username == "" {
+		return nil, fmt.Errorf("%s is required, or a login from %s or %s", envServiceUsername, credentials.EnvNetrc, credentials.EnvHelper)
 	}
 
 	port, err := getEnvPortService(envServicePort, 22)
@@ -122,8 +130,8 @@ func getSSHConfig() (*SSHConfig, error) {
 	return &SSHConfig{
 		Host:     host,
 		Port:     port,
-		User:     username,
-		Password: password,
+		User:     creds.Username,
+		Password: creds.Password,
 		Timeout:  10 * time.Second,
 	}, nil
 }
@@ -0,0 +1,59 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// listStorageContainer fetches a single page of StorageContainer resources from the v4 API
+func listStorageContainer(ctx context.Context, client *client.NutanixClient, opts V4ListOptions) (interface{}, error) {
+	v4Client, err := client.V4()
+	if err != nil {
+		return nil, err
+	}
+
+	return v4Client.StorageContainerAPI.GetAllStorageContainers(opts.Page, opts.Limit, opts.Filter, opts.OrderBy, opts.Select)
+}
+
+// StorageContainerList defines the StorageContainer list tool
+func StorageContainerList() mcp.Tool {
+	opts := []mcp.ToolOption{
+		mcp.WithDescription("List storage_container resources one page at a time using the v4 API"),
+		mcp.WithString("filter",
+			mcp.Description("Optional OData $filter expression, e.g. startswith(name, 'prod')"),
+		),
+		withDetailArgument(),
+		withJQArgument(),
+		withFormatArgument(),
+		withMaxBytesArgument(),
+		withProfileArgument(),
+	}
+
+	return mcp.NewTool("storage_container_list", append(opts, withODataArguments()...)...)
+}
+
+// StorageContainerListHandler implements the handler for the StorageContainer list tool
+func StorageContainerListHandler() server.ToolHandlerFunc {
+	return CreateV4ListToolHandler(resources.ResourceTypeStorageContainer, listStorageContainer)
+}
+
+// StorageContainerCount defines the StorageContainer count tool
+func StorageContainerCount() mcp.Tool {
+	return mcp.NewTool("storage_container_count",
+		mcp.WithDescription("Count storage_container resources using the v4 API"),
+		mcp.WithString("filter",
+			mcp.Description("Optional OData $filter expression, e.g. startswith(name, 'prod')"),
+		),
+		withProfileArgument(),
+	)
+}
+
+// StorageContainerCountHandler implements the handler for the StorageContainer count tool
+func StorageContainerCountHandler() server.ToolHandlerFunc {
+	return CreateV4CountToolHandler(resources.ResourceTypeStorageContainer, listStorageContainer)
+}
@@ -0,0 +1,411 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	envMaxBytes = "MCP_MAX_BYTES"
+
+	// defaultMaxBytes keeps responses to roughly 25k tokens
+	defaultMaxBytes = 100000
+
+	continuationTTL  = 15 * time.Minute
+	maxContinuations = 100
+)
+
+// splitArrayKeys are the top-level JSON fields whose arrays are split on entity boundaries
+var splitArrayKeys = []string{"entities", "data"}
+
+// responseChunker splits a response into units that are never cut, such as entities or lines
+type responseChunker struct {
+	// Key is the top-level field holding the split array, empty for a top-level array
+	Key string
+	// Lines is set when the units are lines of text rather than JSON array elements
+	Lines bool
+	// Rows is set when the lines are table rows, which are never split even if a row exceeds the budget
+	Rows bool
+	// Header is repeated at the top of every chunk of lines, e.g. the header of a table
+	Header string
+	Units  []string
+	// Links are the embedded resources of the entities, returned with the units they occur in
+	Links *entityLinks
+}
+
+// render renders the given units as a standalone chunk, using envelope for the fields around the array
+func (c *responseChunker) render(units []string, envelope map[string]json.RawMessage) string {
+	if c.Lines {
+		return c.Header + strings.Join(units, "")
+	}
+
+	array := "[" + strings.Join(units, ",") + "]"
+	if c.Key == "" {
+		return array
+	}
+
+	fields := map[string]json.RawMessage{}
+	for key, value := range envelope {
+		fields[key] = value
+	}
+	fields[c.Key] = json.RawMessage(array)
+
+	data, _ := json.Marshal(fields)
+	return string(data)
+}
+
+// take returns the number of leading units whose rendering fits maxBytes with their links, at least one.
+// A single line longer than maxBytes is split so that progress is always made, a single row is returned whole.
+func (c *responseChunker) take(maxBytes int64, overhead int) int {
+	size := int64(overhead)
+	for i, unit := range c.Units {
+		_, linksSize := c.Links.in(unit)
+		size += int64(len(unit)) + linksSize
+		if !c.Lines && i > 0 {
+			size++ // separating comma
+		}
+		if size <= maxBytes {
+			continue
+		}
+		if i > 0 {
+			return i
+		}
+		if c.Lines && !c.Rows {
+			cut := utf8SafeCut(unit, int(maxBytes)-overhead)
+			if cut > 0 {
+				c.Units = append([]string{unit[:cut], unit[cut:]}, c.Units[1:]...)
+			}
+		}
+		return 1
+	}
+
+	return len(c.Units)
+}
+
+// continuation is the remainder of a truncated response, with the fields around its split array
+type continuation struct {
+	chunker  *responseChunker
+	envelope map[string]json.RawMessage
+	returned int
+	expires  time.Time
+}
+
+// continuationStore holds the remainders of truncated responses until they are fetched or expire
+type continuationStore struct {
+	mu      sync.Mutex
+	entries map[string]*continuation
+}
+
+var continuations = &continuationStore{entries: map[string]*continuation{}}
+
+func (s *continuationStore) put(entry *continuation) (string, error) {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to create continuation handle: %w", err)
+	}
+	handle := hex.EncodeToString(buf)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var oldest string
+	for key, existing := range s.entries {
+		if now.After(existing.expires) {
+			delete(s.entries, key)
+			continue
+		}
+		if oldest == "" || existing.expires.Before(s.entries[oldest].expires) {
+			oldest = key
+		}
+	}
+	if len(s.entries) >= maxContinuations && oldest != "" {
+		delete(s.entries, oldest)
+	}
+
+	entry.expires = now.Add(continuationTTL)
+	s.entries[handle] = entry
+
+	return handle, nil
+}
+
+func (s *continuationStore) take(handle string) (*continuation, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[handle]
+	delete(s.entries, handle)
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+
+	return entry, true
+}
+
+// TruncationInfo tells the caller that a response was truncated and how to get the rest
+type TruncationInfo struct {
+	Truncated    bool   `json:"truncated"`
+	MaxBytes     int64  `json:"max_bytes"`
+	Unit         string `json:"unit"`
+	Returned     int    `json:"returned"`
+	Remaining    int    `json:"remaining"`
+	Continuation string `json:"continuation"`
+	Hint         string `json:"hint"`
+}
+
+// withMaxBytesArgument adds the per-call response budget argument to a tool
+func withMaxBytesArgument() mcp.ToolOption {
+	return mcp.WithNumber("max_bytes",
+		mcp.Description(fmt.Sprintf("Optional response size budget in bytes (default %s or %d, 0 disables). Larger responses are truncated on entity or line boundaries and return a continuation handle for response_continue", envMaxBytes, defaultMaxBytes)),
+	)
+}
+
+// parseMaxBytes reads the max_bytes argument, falling back to the server-wide budget
+func parseMaxBytes(request mcp.CallToolRequest) (int64, error) {
+	fallback := int64(defaultMaxBytes)
+	if raw := strings.TrimSpace(os.Getenv(envMaxBytes)); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || parsed < 0 {
+			return 0, fmt.Errorf("%s must be a non-negative integer", envMaxBytes)
+		}
+		fallback = parsed
+	}
+
+	maxBytes, err := int64Argument(request, "max_bytes", fallback)
+	if err != nil {
+		return 0, err
+	}
+	if maxBytes < 0 {
+		return 0, fmt.Errorf("max_bytes must be a non-negative integer")
+	}
+
+	return maxBytes, nil
+}
+
+// newJSONResult returns a tool result for JSON output, truncated on entity boundaries to the response budget.
+// Any extra contents, such as page info, are appended unchanged, followed by the links of the returned entities.
+func newJSONResult(request mcp.CallToolRequest, data []byte, links *entityLinks, extra ...mcp.Content) (*mcp.CallToolResult, error) {
+	maxBytes, err := parseMaxBytes(request)
+	if err != nil {
+		return nil, err
+	}
+	if fits(maxBytes, string(data), links) {
+		return newLinkedContentsResult(string(data), links, nil, extra), nil
+	}
+
+	chunker, envelope, err := newJSONChunker(data)
+	if err != nil {
+		return nil, err
+	}
+	chunker.Links = links
+
+	return truncateResult(chunker, envelope, maxBytes, 0, extra)
+}
+
+// newTextResult returns a tool result for text output with secrets masked, truncated on line boundaries to the response budget
+func newTextResult(request mcp.CallToolRequest, text string, links *entityLinks, extra ...mcp.Content) (*mcp.CallToolResult, error) {
+	// Mask secrets in command output and logs, JSON output is redacted while it is encoded
+	text, audit := redactText(text)
+	extra = append(extra, audit...)
+
+	maxBytes, err := parseMaxBytes(request)
+	if err != nil {
+		return nil, err
+	}
+	if fits(maxBytes, text, links) {
+		return newLinkedContentsResult(text, links, nil, extra), nil
+	}
+
+	chunker := newLineChunker(text)
+	chunker.Links = links
+	return truncateResult(chunker, nil, maxBytes, 0, extra)
+}
+
+// newRowsResult returns a tool result for a table, truncated between rows to the response budget.
+// The header is repeated at the top of every continuation chunk.
+func newRowsResult(request mcp.CallToolRequest, header string, rows []string, links *entityLinks, extra ...mcp.Content) (*mcp.CallToolResult, error) {
+	text := header + strings.Join(rows, "")
+	maxBytes, err := parseMaxBytes(request)
+	if err != nil {
+		return nil, err
+	}
+	if fits(maxBytes, text, links) {
+		return newLinkedContentsResult(text, links, nil, extra), nil
+	}
+
+	chunker := &responseChunker{Lines: true, Rows: true, Header: header, Units: rows, Links: links}
+	return truncateResult(chunker, nil, maxBytes, 0, extra)
+}
+
+// fits reports whether a whole response fits the response budget with the links of its entities
+func fits(maxBytes int64, text string, links *entityLinks) bool {
+	if maxBytes == 0 {
+		return true
+	}
+	_, linksSize := links.in(text)
+
+	return int64(len(text))+linksSize <= maxBytes
+}
+
+// newJSONChunker splits the entities or data array of a JSON object, a top-level JSON array,
+// or else the lines of the indented JSON document
+func newJSONChunker(data []byte) (*responseChunker, map[string]json.RawMessage, error) {
+	trimmed := bytes.TrimSpace(data)
+
+	var elements []json.RawMessage
+	if len(trimmed) > 0 && trimmed[0] == '[' && json.Unmarshal(trimmed, &elements) == nil {
+		return &responseChunker{Units: rawUnits(elements)}, nil, nil
+	}
+
+	var envelope map[string]json.RawMessage
+	if len(trimmed) > 0 && trimmed[0] == '{' && json.Unmarshal(trimmed, &envelope) == nil {
+		for _, key := range splitArrayKeys {
+			if json.Unmarshal(envelope[key], &elements) == nil && len(elements) > 0 {
+				delete(envelope, key)
+				return &responseChunker{Key: key, Units: rawUnits(elements)}, envelope, nil
+			}
+		}
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, trimmed, "", "  "); err != nil {
+		return nil, nil, fmt.Errorf("failed to split response: %w", err)
+	}
+
+	return newLineChunker(indented.String()), nil, nil
+}
+
+func newLineChunker(text string) *responseChunker {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return &responseChunker{Lines: true, Units: lines}
+}
+
+// truncateResult returns the units of the chunker that fit maxBytes and stores the rest for response_continue
+func truncateResult(chunker *responseChunker, envelope map[string]json.RawMessage, maxBytes int64, returned int, extra []mcp.Content) (*mcp.CallToolResult, error) {
+	overhead := len(chunker.render(nil, envelope))
+	n := chunker.take(maxBytes, overhead)
+	text := chunker.render(chunker.Units[:n], envelope)
+
+	remaining := &responseChunker{Key: chunker.Key, Lines: chunker.Lines, Rows: chunker.Rows, Header: chunker.Header, Units: chunker.Units[n:], Links: chunker.Links}
+	if len(remaining.Units) == 0 {
+		return newLinkedContentsResult(text, chunker.Links, nil, extra), nil
+	}
+
+	handle, err := continuations.put(&continuation{chunker: remaining, envelope: envelope, returned: returned + n})
+	if err != nil {
+		return nil, err
+	}
+
+	unit := "entities"
+	switch {
+	case chunker.Rows:
+		unit = "rows"
+	case chunker.Lines:
+		unit = "lines"
+	}
+	info := &TruncationInfo{
+		Truncated:    true,
+		MaxBytes:     maxBytes,
+		Unit:         unit,
+		Returned:     returned + n,
+		Remaining:    len(remaining.Units),
+		Continuation: handle,
+		Hint:         "call response_continue with this continuation handle to get the rest",
+	}
+
+	return newLinkedContentsResult(text, chunker.Links, info, extra), nil
+}
+
+// newLinkedContentsResult returns a tool result whose extra contents are followed by the links of the entities in the text
+func newLinkedContentsResult(text string, links *entityLinks, info *TruncationInfo, extra []mcp.Content) *mcp.CallToolResult {
+	linked, _ := links.in(text)
+
+	return newTextContentsResult(text, info, append(append([]mcp.Content{}, extra...), linked...))
+}
+
+func newTextContentsResult(text string, info *TruncationInfo, extra []mcp.Content) *mcp.CallToolResult {
+	contents := append([]mcp.Content{mcp.NewTextContent(text)}, extra...)
+	if info != nil {
+		infoBytes, _ := json.Marshal(info)
+		contents = append(contents, mcp.NewTextContent(string(infoBytes)))
+	}
+
+	return &mcp.CallToolResult{Content: contents}
+}
+
+// ResponseContinue defines the response_continue tool
+func ResponseContinue() mcp.Tool {
+	return mcp.NewTool("response_continue",
+		mcp.WithDescription("Get the rest of a truncated response using the continuation handle it returned"),
+		mcp.WithString("continuation",
+			mcp.Required(),
+			mcp.Description("Continuation handle returned with a truncated response"),
+		),
+		withMaxBytesArgument(),
+	)
+}
+
+// ResponseContinueHandler implements the handler for the response_continue tool
+func ResponseContinueHandler() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		handle := stringArgument(request, "continuation")
+		if handle == "" {
+			return nil, fmt.Errorf("continuation is required")
+		}
+
+		// Validate the arguments before taking the continuation, so a bad call does not consume it
+		maxBytes, err := parseMaxBytes(request)
+		if err != nil {
+			return nil, err
+		}
+		if maxBytes == 0 {
+			maxBytes = math.MaxInt64
+		}
+
+		entry, ok := continuations.take(handle)
+		if !ok {
+			return nil, fmt.Errorf("continuation %s is unknown or expired, please repeat the original call", handle)
+		}
+
+		return truncateResult(entry.chunker, entry.envelope, maxBytes, entry.returned, nil)
+	}
+}
+
+func rawUnits(elements []json.RawMessage) []string {
+	units := make([]string, len(elements))
+	for i, element := range elements {
+		units[i] = string(element)
+	}
+	return units
+}
+
+// utf8SafeCut returns the largest index not above n that does not split a rune
+func utf8SafeCut(s string, n int) int {
+	if n <= 0 {
+		return 0
+	}
+	if n >= len(s) {
+		return len(s)
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return n
+}
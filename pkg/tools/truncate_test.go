@@ -0,0 +1,179 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func maxBytesRequest(maxBytes interface{}) mcp.CallToolRequest {
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]interface{}{"max_bytes": maxBytes}
+	return request
+}
+
+func resultTexts(t *testing.T, result *mcp.CallToolResult) []string {
+	texts := make([]string, 0, len(result.Content))
+	for _, content := range result.Content {
+		text, ok := content.(mcp.TextContent)
+		require.True(t, ok)
+		texts = append(texts, text.Text)
+	}
+	return texts
+}
+
+func truncationInfo(t *testing.T, text string) TruncationInfo {
+	var info TruncationInfo
+	require.NoError(t, json.Unmarshal([]byte(text), &info))
+	require.True(t, info.Truncated)
+	return info
+}
+
+func continueResponse(t *testing.T, handle string, maxBytes interface{}) []string {
+	request := maxBytesRequest(maxBytes)
+	request.Params.Arguments["continuation"] = handle
+	result, err := ResponseContinueHandler()(context.Background(), request)
+	require.NoError(t, err)
+	return resultTexts(t, result)
+}
+
+func TestNewJSONResultSplitsEntities(t *testing.T) {
+	entities := make([]string, 10)
+	for i := range entities {
+		entities[i] = fmt.Sprintf(`{"name":"vm-%02d","padding":"%s"}`, i, strings.Repeat("x", 20))
+	}
+	data := []byte(`{"entities":[` + strings.Join(entities, ",") + `],"metadata":{"total_matches":10}}`)
+
+	result, err := newJSONResult(maxBytesRequest(float64(200)), data, nil, mcp.NewTextContent("page"))
+	require.NoError(t, err)
+	texts := resultTexts(t, result)
+	require.Len(t, texts, 3)
+	assert.LessOrEqual(t, len(texts[0]), 200)
+	assert.Equal(t, "page", texts[1])
+
+	var first struct {
+		Entities []map[string]string `json:"entities"`
+		Metadata map[string]int      `json:"metadata"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(texts[0]), &first))
+	assert.Equal(t, 10, first.Metadata["total_matches"])
+	names := []string{}
+	for _, entity := range first.Entities {
+		names = append(names, entity["name"])
+	}
+
+	info := truncationInfo(t, texts[2])
+	assert.Equal(t, "entities", info.Unit)
+	assert.Equal(t, len(first.Entities), info.Returned)
+	assert.Equal(t, 10-info.Returned, info.Remaining)
+
+	// An invalid max_bytes leaves the continuation usable
+	request := maxBytesRequest(float64(-1))
+	request.Params.Arguments["continuation"] = info.Continuation
+	_, err = ResponseContinueHandler()(context.Background(), request)
+	assert.Error(t, err)
+
+	// Fetch the rest in chunks until no continuation is returned
+	handle := info.Continuation
+	for handle != "" {
+		texts = continueResponse(t, handle, float64(200))
+		var chunk struct {
+			Entities []map[string]string `json:"entities"`
+			Metadata map[string]int      `json:"metadata"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(texts[0]), &chunk))
+		assert.LessOrEqual(t, len(texts[0]), 200)
+		// Every chunk keeps the fields around the entities
+		assert.Equal(t, 10, chunk.Metadata["total_matches"])
+		for _, entity := range chunk.Entities {
+			names = append(names, entity["name"])
+		}
+		handle = ""
+		if len(texts) == 2 {
+			handle = truncationInfo(t, texts[1]).Continuation
+		}
+	}
+	require.Len(t, names, 10)
+	assert.Equal(t, "vm-09", names[9])
+}
+
+func TestNewTextResultSplitsLines(t *testing.T) {
+	text := "line one\nline two\nline three\n"
+
+	result, err := newTextResult(maxBytesRequest("20"), text, nil)
+	require.NoError(t, err)
+	texts := resultTexts(t, result)
+	require.Len(t, texts, 2)
+	assert.Equal(t, "line one\nline two\n", texts[0])
+
+	info := truncationInfo(t, texts[1])
+	assert.Equal(t, "lines", info.Unit)
+	assert.Equal(t, 1, info.Remaining)
+
+	texts = continueResponse(t, info.Continuation, float64(0))
+	assert.Equal(t, []string{"line three\n"}, texts)
+
+	// Unknown or already used handles are rejected
+	request := maxBytesRequest(float64(0))
+	request.Params.Arguments["continuation"] = info.Continuation
+	_, err = ResponseContinueHandler()(context.Background(), request)
+	assert.Error(t, err)
+}
+
+func TestNewTextResultSplitsLongLine(t *testing.T) {
+	text := strings.Repeat("é", 10)
+
+	result, err := newTextResult(maxBytesRequest(float64(5)), text, nil)
+	require.NoError(t, err)
+	texts := resultTexts(t, result)
+	require.Len(t, texts, 2)
+	assert.Equal(t, "éé", texts[0])
+
+	texts = continueResponse(t, truncationInfo(t, texts[1]).Continuation, float64(0))
+	assert.Equal(t, strings.Repeat("é", 8), texts[0])
+}
+
+func TestNewJSONResultWithinBudget(t *testing.T) {
+	data := []byte(`{"name":"vm-01"}`)
+
+	result, err := newJSONResult(maxBytesRequest(float64(0)), []byte(strings.Repeat(" ", 200000)+`{}`), nil)
+	require.NoError(t, err)
+	assert.Len(t, result.Content, 1)
+
+	result, err = newJSONResult(mcp.CallToolRequest{}, data, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{string(data)}, resultTexts(t, result))
+
+	_, err = newJSONResult(maxBytesRequest(float64(-1)), data, nil)
+	assert.Error(t, err)
+}
+
+func TestNewJSONChunkerFallsBackToLines(t *testing.T) {
+	chunker, envelope, err := newJSONChunker([]byte(`{"a":{"b":1},"c":[]}`))
+	require.NoError(t, err)
+	assert.Nil(t, envelope)
+	assert.True(t, chunker.Lines)
+	assert.Equal(t, "{\n", chunker.Units[0])
+
+	chunker, _, err = newJSONChunker([]byte(`[1,2,3]`))
+	require.NoError(t, err)
+	assert.False(t, chunker.Lines)
+	assert.Equal(t, []string{"1", "2", "3"}, chunker.Units)
+	assert.Equal(t, "[1,2]", chunker.render(chunker.Units[:2], nil))
+}
+
+func TestNewTextResultRedactsSecrets(t *testing.T) {
+	result, err := newTextResult(maxBytesRequest(float64(0)), "export NUTANIX_PASSWORD=nutanix/4u\n", nil)
+	require.NoError(t, err)
+
+	texts := resultTexts(t, result)
+	require.Len(t, texts, 2)
+	assert.Equal(t, "export NUTANIX_PASSWORD=[REDACTED]\n", texts[0])
+	assert.JSONEq(t, `{"redacted": 1, "rules": {"password": 1}}`, texts[1])
+}
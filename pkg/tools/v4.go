@@ -0,0 +1,219 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+	"github.com/thunderboltsid/mcp-nutanix/internal/json"
+	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	defaultV4Limit = 50
+	maxV4Limit     = 100
+)
+
+// V4ListOptions holds the OData query options of a v4 list call.
+// Nil fields are omitted from the request.
+type V4ListOptions struct {
+	Page    *int
+	Limit   *int
+	Filter  *string
+	OrderBy *string
+	Select  *string
+}
+
+// V4ListResourceFunc defines a function that handles listing a v4 resource type
+type V4ListResourceFunc func(ctx context.Context, client *client.NutanixClient, opts V4ListOptions) (interface{}, error)
+
+// withODataArguments adds the OData ordering, projection and paging arguments to a v4 list tool
+func withODataArguments() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("orderby",
+			mcp.Description("Optional OData $orderby expression, e.g. name desc"),
+		),
+		mcp.WithString("select",
+			mcp.Description("Optional OData $select list of properties to return, e.g. extId,name"),
+		),
+		mcp.WithNumber("page",
+			mcp.Description("Optional zero-based OData $page number (default 0)"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(fmt.Sprintf("Optional OData $limit of entities per page (default %d, max %d)", defaultV4Limit, maxV4Limit)),
+		),
+	}
+}
+
+// parseV4ListOptions reads the OData arguments from a v4 list tool request
+func parseV4ListOptions(request mcp.CallToolRequest) (V4ListOptions, error) {
+	opts := V4ListOptions{}
+
+	page, err := int64Argument(request, "page", 0)
+	if err != nil {
+		return opts, err
+	}
+	if page < 0 {
+		return opts, fmt.Errorf("page must not be negative")
+	}
+
+	limit, err := int64Argument(request, "limit", defaultV4Limit)
+	if err != nil {
+		return opts, err
+	}
+	if limit < 1 || limit > maxV4Limit {
+		return opts, fmt.Errorf("limit must be between 1 and %d", maxV4Limit)
+	}
+
+	pageInt, limitInt := int(page), int(limit)
+	opts.Page = &pageInt
+	opts.Limit = &limitInt
+	opts.Filter = optionalStringArgument(request, "filter")
+	opts.OrderBy = optionalStringArgument(request, "orderby")
+	opts.Select = optionalStringArgument(request, "select")
+
+	return opts, nil
+}
+
+// CreateV4ListToolHandler creates a generic tool handler for listing v4 resources
+func CreateV4ListToolHandler(
+	resourceType resources.ResourceType,
+	listFunc V4ListResourceFunc,
+) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get the Prism client of the selected profile
+		prismClient, err := prismClientFor(request)
+		if err != nil {
+			return nil, err
+		}
+
+		// Get the OData options if provided
+		opts, err := parseV4ListOptions(request)
+		if err != nil {
+			return nil, err
+		}
+
+		// Get the paths to strip for the requested detail level
+		stripPaths, err := parseDetailArgument(resourceType, request)
+		if err != nil {
+			return nil, err
+		}
+
+		// Compile the jq expression if provided, reporting invalid expressions as tool errors
+		query, err := parseJQArgument(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		// Get the output format, tables use the selected properties as columns
+		format, err := parseFormatArgument(request)
+		if err != nil {
+			return nil, err
+		}
+
+		// List a single page of resources
+		resp, err := listFunc(ctx, prismClient, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", resourceType, err)
+		}
+
+		// Convert to JSON, linking every entity to its resource, e.g. vmm_vm://{extId}
+		cjson := json.StrippedJSONEncoder(resp, stripPaths, nil)
+		links := newEntityLinks(resourceType, prismClient.Profile())
+		cjson.EntityURI = links.EntityURI
+		jsonBytes, err := cjson.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %w", resourceType, err)
+		}
+
+		// Run the jq expression if provided, reporting failures as tool errors
+		jsonBytes, err = runJQQuery(ctx, query, jsonBytes)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		var columns []string
+		if opts.Select != nil {
+			columns = splitList(*opts.Select)
+		}
+		return newFormattedResult(request, format, jsonBytes, columns, links, redactionContents(cjson.Redactions)...)
+	}
+}
+
+// CreateV4CountToolHandler creates a generic tool handler for counting v4 resources.
+// It fetches a single entity and reports the total from the response metadata.
+func CreateV4CountToolHandler(
+	resourceType resources.ResourceType,
+	listFunc V4ListResourceFunc,
+) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get the Prism client of the selected profile
+		prismClient, err := prismClientFor(request)
+		if err != nil {
+			return nil, err
+		}
+
+		limit := 1
+		opts := V4ListOptions{
+			Limit:  &limit,
+			Filter: optionalStringArgument(request, "filter"),
+		}
+
+		resp, err := listFunc(ctx, prismClient, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", resourceType, err)
+		}
+
+		count, ok := totalAvailableResults(resp)
+		if !ok {
+			return nil, fmt.Errorf("failed to count %s: response has no totalAvailableResults", resourceType)
+		}
+
+		res := map[string]interface{}{
+			"resource_type": resourceType,
+			"count":         count,
+		}
+
+		// Convert to JSON
+		cjson := json.RegularJSONEncoder(res)
+		jsonBytes, err := cjson.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s count: %w", resourceType, err)
+		}
+
+		return mcp.NewToolResultText(string(jsonBytes)), nil
+	}
+}
+
+// totalAvailableResults returns Metadata.TotalAvailableResults of a v4 list response
+func totalAvailableResults(resp interface{}) (int64, bool) {
+	v := reflect.Indirect(reflect.ValueOf(resp))
+	if v.Kind() != reflect.Struct {
+		return 0, false
+	}
+
+	metadata := v.FieldByName("Metadata")
+	if !metadata.IsValid() || metadata.Kind() != reflect.Ptr || metadata.IsNil() {
+		return 0, false
+	}
+
+	total := metadata.Elem().FieldByName("TotalAvailableResults")
+	if !total.IsValid() || total.Kind() != reflect.Ptr || total.IsNil() {
+		return 0, false
+	}
+
+	return total.Elem().Int(), true
+}
+
+func optionalStringArgument(request mcp.CallToolRequest, name string) *string {
+	value := stringArgument(request, name)
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
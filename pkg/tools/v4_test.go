@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseV4ListOptions(t *testing.T) {
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]interface{}{
+		"filter":  "startswith(name, 'web')",
+		"orderby": "name desc",
+		"page":    "2",
+	}
+
+	opts, err := parseV4ListOptions(request)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *opts.Page)
+	assert.Equal(t, defaultV4Limit, *opts.Limit)
+	assert.Equal(t, "startswith(name, 'web')", *opts.Filter)
+	assert.Equal(t, "name desc", *opts.OrderBy)
+	assert.Nil(t, opts.Select)
+
+	request.Params.Arguments["limit"] = "500"
+	_, err = parseV4ListOptions(request)
+	assert.Error(t, err)
+}
+
+func TestODataPagingArgumentsAreNumbers(t *testing.T) {
+	tool := mcp.NewTool("odata_test", withODataArguments()...)
+	for _, name := range []string{"page", "limit"} {
+		assert.Equal(t, "number", tool.InputSchema.Properties[name].(map[string]interface{})["type"], name)
+	}
+}
+
+func TestTotalAvailableResults(t *testing.T) {
+	type metadata struct {
+		TotalAvailableResults *int
+	}
+	type response struct {
+		Metadata *metadata
+	}
+
+	total := 42
+	count, ok := totalAvailableResults(&response{Metadata: &metadata{TotalAvailableResults: &total}})
+	assert.True(t, ok)
+	assert.Equal(t, int64(42), count)
+
+	_, ok = totalAvailableResults(&response{})
+	assert.False(t, ok)
+}
@@ -2,6 +2,7 @@ package tools
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/thunderboltsid/mcp-nutanix/internal/client"
 	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
@@ -10,14 +11,25 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 )
 
-// VM defines the VM tool
+// VMList defines the VM list tool
 func VMList() mcp.Tool {
-	return mcp.NewTool("vm_list",
-		mcp.WithDescription("List vm resources"),
+	opts := []mcp.ToolOption{
+		mcp.WithDescription("List vm resources one page at a time"),
 		mcp.WithString("filter",
-			mcp.Description("Optional text filter (interpreted by LLM)"),
+			mcp.Description("Optional Prism FIQL filter, e.g. vm_name==web.*;power_state==on (';' is AND, ',' is OR)"),
 		),
-	)
+		mcp.WithString("fields",
+			mcp.Description("Optional comma-separated entity field paths to return, e.g. spec.name,metadata.uuid"),
+		),
+		withDetailArgument(),
+		withViewArgument(),
+		withJQArgument(),
+		withFormatArgument(),
+		withMaxBytesArgument(),
+		withProfileArgument(),
+	}
+
+	return mcp.NewTool("vm_list", append(opts, withPagingArguments()...)...)
 }
 
 // VMListHandler implements the handler for the VM list tool
@@ -25,11 +37,14 @@ func VMListHandler() server.ToolHandlerFunc {
 	return CreateListToolHandler(
 		resources.ResourceTypeVM,
 		// Define the ListResourceFunc implementation
-		func(ctx context.Context, client *client.NutanixClient, filter string) (interface{}, error) {
-
-			// Use ListAll function to get all resources
-			return client.V3().ListAllVM(ctx, "")
+		func(ctx context.Context, client *client.NutanixClient, opts ListOptions) (interface{}, error) {
+			v3Client, err := client.V3()
+			if err != nil {
+				return nil, err
+			}
 
+			// Fetch a single page using the paging and sorting options
+			return v3Client.ListVM(ctx, opts.DSMetadata("vm"))
 		},
 	)
 }
@@ -39,8 +54,9 @@ func VMCount() mcp.Tool {
 	return mcp.NewTool("vm_count",
 		mcp.WithDescription("Count vm resources"),
 		mcp.WithString("filter",
-			mcp.Description("Optional text filter (interpreted by LLM)"),
+			mcp.Description("Optional Prism FIQL filter, e.g. vm_name==web.*;power_state==on (';' is AND, ',' is OR)"),
 		),
+		withProfileArgument(),
 	)
 }
 
@@ -49,18 +65,26 @@ func VMCountHandler() server.ToolHandlerFunc {
 	return CreateCountToolHandler(
 		resources.ResourceTypeVM,
 		// Define the ListResourceFunc implementation
-		func(ctx context.Context, client *client.NutanixClient, filter string) (interface{}, error) {
-
-			// Use ListAll function to get all resources
-			resp, err := client.V3().ListAllVM(ctx, "")
+		func(ctx context.Context, client *client.NutanixClient, opts ListOptions) (interface{}, error) {
+			v3Client, err := client.V3()
+			if err != nil {
+				return nil, err
+			}
 
+			// Fetch a single entity; the server-side total covers the rest
+			opts.Length = 1
+			resp, err := v3Client.ListVM(ctx, opts.DSMetadata("vm"))
 			if err != nil {
 				return nil, err
 			}
+			count, ok := totalMatches(resp)
+			if !ok {
+				return nil, fmt.Errorf("response has no total_matches")
+			}
 
 			res := map[string]interface{}{
 				"resource_type": "VM",
-				"count":         len(resp.Entities),
+				"count":         count,
 				"metadata":      resp.Metadata,
 			}
 
@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/json"
+	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// VMGraph defines the vm_graph tool
+func VMGraph() mcp.Tool {
+	return mcp.NewTool("vm_graph",
+		mcp.WithDescription("Resolve what a VM is attached to: cluster, host, subnets, images backing its disks, volume groups, categories, project and owner, by name"),
+		mcp.WithString("uuid",
+			mcp.Required(),
+			mcp.Description("UUID of the VM"),
+		),
+		withProfileArgument(),
+	)
+}
+
+// VMGraphHandler implements the handler for the vm_graph tool
+func VMGraphHandler() server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Get the Prism client of the selected profile
+		prismClient, err := prismClientFor(request)
+		if err != nil {
+			return nil, err
+		}
+
+		uuid := stringArgument(request, "uuid")
+		if uuid == "" {
+			return nil, fmt.Errorf("uuid is required")
+		}
+
+		graph, err := resources.ResolveVMGraph(ctx, prismClient, uuid)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve vm %s: %w", uuid, err)
+		}
+
+		// Convert to JSON
+		cjson := json.CustomJSONEncoder(graph)
+		jsonBytes, err := cjson.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal vm graph: %w", err)
+		}
+
+		return newTextContentsResult(string(jsonBytes), nil, redactionContents(cjson.Redactions)), nil
+	}
+}
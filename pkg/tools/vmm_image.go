@@ -0,0 +1,59 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// listVmmImage fetches a single page of VmmImage resources from the v4 API
+func listVmmImage(ctx context.Context, client *client.NutanixClient, opts V4ListOptions) (interface{}, error) {
+	v4Client, err := client.V4()
+	if err != nil {
+		return nil, err
+	}
+
+	return v4Client.ImagesApiInstance.ListImages(opts.Page, opts.Limit, opts.Filter, opts.OrderBy, opts.Select)
+}
+
+// VmmImageList defines the VmmImage list tool
+func VmmImageList() mcp.Tool {
+	opts := []mcp.ToolOption{
+		mcp.WithDescription("List vmm_image resources one page at a time using the v4 API"),
+		mcp.WithString("filter",
+			mcp.Description("Optional OData $filter expression, e.g. startswith(name, 'prod')"),
+		),
+		withDetailArgument(),
+		withJQArgument(),
+		withFormatArgument(),
+		withMaxBytesArgument(),
+		withProfileArgument(),
+	}
+
+	return mcp.NewTool("vmm_image_list", append(opts, withODataArguments()...)...)
+}
+
+// VmmImageListHandler implements the handler for the VmmImage list tool
+func VmmImageListHandler() server.ToolHandlerFunc {
+	return CreateV4ListToolHandler(resources.ResourceTypeVmmImage, listVmmImage)
+}
+
+// VmmImageCount defines the VmmImage count tool
+func VmmImageCount() mcp.Tool {
+	return mcp.NewTool("vmm_image_count",
+		mcp.WithDescription("Count vmm_image resources using the v4 API"),
+		mcp.WithString("filter",
+			mcp.Description("Optional OData $filter expression, e.g. startswith(name, 'prod')"),
+		),
+		withProfileArgument(),
+	)
+}
+
+// VmmImageCountHandler implements the handler for the VmmImage count tool
+func VmmImageCountHandler() server.ToolHandlerFunc {
+	return CreateV4CountToolHandler(resources.ResourceTypeVmmImage, listVmmImage)
+}
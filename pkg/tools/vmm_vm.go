@@ -0,0 +1,59 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// listVmmVM fetches a single page of VmmVM resources from the v4 API
+func listVmmVM(ctx context.Context, client *client.NutanixClient, opts V4ListOptions) (interface{}, error) {
+	v4Client, err := client.V4()
+	if err != nil {
+		return nil, err
+	}
+
+	return v4Client.VmApiInstance.ListVms(opts.Page, opts.Limit, opts.Filter, opts.OrderBy, opts.Select)
+}
+
+// VmmVMList defines the VmmVM list tool
+func VmmVMList() mcp.Tool {
+	opts := []mcp.ToolOption{
+		mcp.WithDescription("List vmm_vm resources one page at a time using the v4 API"),
+		mcp.WithString("filter",
+			mcp.Description("Optional OData $filter expression, e.g. startswith(name, 'web')"),
+		),
+		withDetailArgument(),
+		withJQArgument(),
+		withFormatArgument(),
+		withMaxBytesArgument(),
+		withProfileArgument(),
+	}
+
+	return mcp.NewTool("vmm_vm_list", append(opts, withODataArguments()...)...)
+}
+
+// VmmVMListHandler implements the handler for the VmmVM list tool
+func VmmVMListHandler() server.ToolHandlerFunc {
+	return CreateV4ListToolHandler(resources.ResourceTypeVmmVM, listVmmVM)
+}
+
+// VmmVMCount defines the VmmVM count tool
+func VmmVMCount() mcp.Tool {
+	return mcp.NewTool("vmm_vm_count",
+		mcp.WithDescription("Count vmm_vm resources using the v4 API"),
+		mcp.WithString("filter",
+			mcp.Description("Optional OData $filter expression, e.g. startswith(name, 'web')"),
+		),
+		withProfileArgument(),
+	)
+}
+
+// VmmVMCountHandler implements the handler for the VmmVM count tool
+func VmmVMCountHandler() server.ToolHandlerFunc {
+	return CreateV4CountToolHandler(resources.ResourceTypeVmmVM, listVmmVM)
+}
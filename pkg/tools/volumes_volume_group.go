@@ -0,0 +1,59 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/thunderboltsid/mcp-nutanix/internal/client"
+	"github.com/thunderboltsid/mcp-nutanix/pkg/resources"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// listVolumesVolumeGroup fetches a single page of VolumesVolumeGroup resources from the v4 API
+func listVolumesVolumeGroup(ctx context.Context, client *client.NutanixClient, opts V4ListOptions) (interface{}, error) {
+	v4Client, err := client.V4()
+	if err != nil {
+		return nil, err
+	}
+
+	return v4Client.VolumeGroupsApiInstance.ListVolumeGroups(opts.Page, opts.Limit, opts.Filter, opts.OrderBy, nil, opts.Select)
+}
+
+// VolumesVolumeGroupList defines the VolumesVolumeGroup list tool
+func VolumesVolumeGroupList() mcp.Tool {
+	opts := []mcp.ToolOption{
+		mcp.WithDescription("List volumes_volume_group resources one page at a time using the v4 API"),
+		mcp.WithString("filter",
+			mcp.Description("Optional OData $filter expression, e.g. startswith(name, 'prod')"),
+		),
+		withDetailArgument(),
+		withJQArgument(),
+		withFormatArgument(),
+		withMaxBytesArgument(),
+		withProfileArgument(),
+	}
+
+	return mcp.NewTool("volumes_volume_group_list", append(opts, withODataArguments()...)...)
+}
+
+// VolumesVolumeGroupListHandler implements the handler for the VolumesVolumeGroup list tool
+func VolumesVolumeGroupListHandler() server.ToolHandlerFunc {
+	return CreateV4ListToolHandler(resources.ResourceTypeVolumesVolumeGroup, listVolumesVolumeGroup)
+}
+
+// VolumesVolumeGroupCount defines the VolumesVolumeGroup count tool
+func VolumesVolumeGroupCount() mcp.Tool {
+	return mcp.NewTool("volumes_volume_group_count",
+		mcp.WithDescription("Count volumes_volume_group resources using the v4 API"),
+		mcp.WithString("filter",
+			mcp.Description("Optional OData $filter expression, e.g. startswith(name, 'prod')"),
+		),
+		withProfileArgument(),
+	)
+}
+
+// VolumesVolumeGroupCountHandler implements the handler for the VolumesVolumeGroup count tool
+func VolumesVolumeGroupCountHandler() server.ToolHandlerFunc {
+	return CreateV4CountToolHandler(resources.ResourceTypeVolumesVolumeGroup, listVolumesVolumeGroup)
+}